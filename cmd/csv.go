@@ -0,0 +1,53 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package cmd
+
+import (
+	"github.com/hobysmith/timeconverter/converter"
+	"github.com/hobysmith/timeconverter/helpers"
+	"github.com/spf13/cobra"
+)
+
+// csvCmd represents the csv command
+var csvCmd = &cobra.Command{
+	Use:   "csv [file] [flags]",
+	Short: "Converts time values in one or more CSV columns",
+	Long: `Converts time values in one or more CSV columns.
+
+CSV is read from the file provided, or from piped input, and written back out with the values in
+the indicated columns converted.  All other fields are written out unchanged, including their quoting.
+Columns can be referenced by header name or by number, starting at 1.`,
+	Example: `  cat billing.csv | timeconverter csv --columns created,updated -i UnixMilli -o RFC3339
+  timeconverter csv billing.csv --columns 1,4 --header=false -i UnixSecs -o DateOnly
+  timeconverter csv events.tsv --columns ts --delimiter tab -i RFC3339 -o UnixSecs`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConversion(func() error {
+			input, closeInput, err := openCommandInput(args, "CSV to convert")
+			if err != nil {
+				return err
+			}
+			defer closeInput()
+
+			tc := converter.New()
+			err = tc.ResolveFormats()
+			if err != nil {
+				return err
+			}
+
+			return tc.ConvertCSV(input, helpers.OP)
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(csvCmd)
+	addConversionFlags(csvCmd)
+	csvCmd.Flags().StringVarP(&helpers.CmdHelpers.CSVColumns, "columns", "c", "", "A comma separated list of the columns to convert, using header names or column numbers starting at 1.")
+	csvCmd.Flags().StringVarP(&helpers.CmdHelpers.CSVDelimiter, "delimiter", "d", ",", "The field delimiter. Use \"tab\" for tab separated values.")
+	csvCmd.Flags().BoolVarP(&helpers.CmdHelpers.CSVHasHeader, "header", "", true, "Indicates that the first record is a header. The header is written out unchanged.")
+	csvCmd.Flags().StringVarP(&helpers.CmdHelpers.BatchErrorModeName, "batch-errors", "", "abort", "How values that fail to convert are handled: abort, passthrough or marker.")
+	csvCmd.Flags().BoolVarP(&helpers.CmdHelpers.PipeMode, "piped", "p", false, "[OPTIONAL] Explicitly indicates that you are piping input in from another app if auto-detection is not working.")
+}
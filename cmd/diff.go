@@ -0,0 +1,49 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package cmd
+
+import (
+	"github.com/hobysmith/timeconverter/converter"
+	"github.com/hobysmith/timeconverter/helpers"
+	"github.com/spf13/cobra"
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff fromValue toValue [flags]",
+	Short: "Outputs the time elapsed between two time values",
+	Long: `Outputs the time elapsed between two time values.
+
+The first value is read using the input format.  The second value is read using --to-input-format,
+or the input format if that is not provided, so the two values can be in different formats.
+The difference is negative if the second value is before the first.
+
+The styles are:
+  go     - a Go duration, like 26h3m4.5s
+  secs   - the total seconds, like 93784.5
+  millis - the total milliseconds, like 93784500
+  iso    - an ISO 8601 duration, like P1DT2H3M4.5S
+  human  - a breakdown by calendar units, like 1 day, 2 hours, 3 minutes, 4 seconds`,
+	Example: `  timeconverter diff 1693668084 1693675284 -i UnixSecs
+  timeconverter diff "2023-09-02 10:21:24 -0500" 1693675284123 -i USDateTimeZ --to-input-format UnixMilli --style millis
+  timeconverter diff 2023-01-31 now --style human -v`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		helpers.CmdHelpers.Value = args[0]
+		helpers.CmdHelpers.DiffToValue = args[1]
+
+		return runConversion(func() error {
+			return converter.New().Diff(false)
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	addConversionFlags(diffCmd)
+	diffCmd.Flags().StringVarP(&helpers.CmdHelpers.DiffToInputFormatName, "to-input-format", "j", "", "The input format of the second value.  If not provided, the input format is used.")
+	diffCmd.Flags().StringVarP(&helpers.CmdHelpers.DiffToInputLayout, "to-input-layout", "k", "", "When the second value's input format is \"custom\" or \"customgo\", this is the layout text.  If not provided, the input layout is used.")
+	diffCmd.Flags().StringVarP(&helpers.CmdHelpers.DiffStyleName, "style", "s", "go", "How the difference is output: go, secs, millis, iso or human.")
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package cmd
+
+import (
+	"github.com/hobysmith/timeconverter/converter"
+	"github.com/hobysmith/timeconverter/helpers"
+	"github.com/spf13/cobra"
+)
+
+// explainCmd represents the explain command
+var explainCmd = &cobra.Command{
+	Use:   "explain dateTimeValue [flags]",
+	Short: "Outputs a report describing everything about a time value",
+	Long: `Outputs a report describing everything about a time value.
+
+The report includes the instant in UTC, local time and the target timezone, the weekday, ISO week,
+day of year, quarter and leap year status, whether daylight savings is in effect along with the
+previous and next zone transitions, how long ago the time was, and the time rendered in every format
+that does not need a layout.
+
+The target timezone is the output timezone, or the local timezone if no output timezone is provided.`,
+	Example: `  timeconverter explain 1693668084
+  timeconverter explain "2023-11-05 01:30:00" -i USDateTime --input-timezone America/Chicago -z America/Chicago`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		helpers.CmdHelpers.Value = args[0]
+
+		return runConversion(func() error {
+			return converter.New().Explain(false)
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+	addInputFlags(explainCmd)
+	explainCmd.Flags().StringVarP(&helpers.CmdHelpers.OutputTargetName, "output-target", "t", "console", "Indicates the type of output. Either console or clipboard.")
+	explainCmd.Flags().StringVarP(&helpers.CmdHelpers.OutputTimeZone, "output-timezone", "z", "", "The target timezone that the time is described in.  If not specified, the local timezone is used.")
+}
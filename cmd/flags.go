@@ -0,0 +1,82 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package cmd
+
+import (
+	"github.com/hobysmith/timeconverter/helpers"
+	"github.com/spf13/cobra"
+)
+
+// addConversionFlags defines the flags that control how values are read, converted and output.
+// These are shared by the root command and any command that converts values, so that they all
+// behave the same way and honor the same defaults.
+func addConversionFlags(cmd *cobra.Command) {
+	addInputFlags(cmd)
+	addOutputFlags(cmd)
+}
+
+// addInputFlags defines the flags that control how values are read and offset.  Commands that read
+// values but have their own output, like explain, use these instead of addConversionFlags.
+func addInputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.InputFormatName, "input-format", "i", "Auto", "The input format. Use \"timeconverter show -f\" for a list of formats. \"Auto\" detects the format from the input value.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.InputLayout, "input-layout", "l", "", "When input format is set to \"custom\" or \"customgo\", this is the layout text.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.InputTimeZone, "input-timezone", "", "", "A timezone used to read input values that do not include a timezone.  If not specified, those values are read as UTC. Can be an IANA country/city ref or a timezone offset like -0700")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.AddDuration, "add", "", "", "A duration to add to the input time, like 90m, 2d3h or 1mo.  Supports y, mo, w, d, h, m, s, ms, us and ns.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.SubtractDuration, "subtract", "", "", "A duration to subtract from the input time, like 90m, 2d3h or 1mo.  Supports y, mo, w, d, h, m, s, ms, us and ns.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.MonthEndModeName, "month-end", "", "clamp", "How adding months handles days that do not exist in the resulting month. \"clamp\" makes Jan 31 + 1mo Feb 28, \"overflow\" makes it Mar 3.")
+	cmd.Flags().BoolVarP(&helpers.CmdHelpers.StrictISO8601, "strict", "", false, "When reading ISO8601 input values, only accept values that follow RFC 3339 exactly, like 2023-09-02T10:21:24Z.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.SnowflakeLayoutName, "snowflake", "", "twitter", "The layout used to read and output Snowflake IDs: twitter, discord, instagram or custom.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.SnowflakeEpochMillis, "snowflake-epoch", "", "", "For the custom snowflake layout, the epoch in Unix milliseconds, like 1288834974657.")
+	cmd.Flags().IntVarP(&helpers.CmdHelpers.SnowflakeTimestampShift, "snowflake-shift", "", helpers.DefaultSnowflakeTimestampShift, "For the custom snowflake layout, the number of bits below the timestamp, which is the worker bits plus the sequence bits.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.TimeScaleName, "time-scale", "", "", "The time scale of both input and output values: utc, tai or gps. Defaults to utc.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.InputTimeScaleName, "input-time-scale", "", "", "The time scale of input values: utc, tai or gps. Overrides --time-scale for input values.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.WeekStartName, "week-start", "", "monday", "The first day of the week, used when truncating or rounding to a week and for start-of-week and end-of-week.")
+}
+
+// addOutputFlags defines the flags that control how converted values are output
+func addOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.OutputTargetName, "output-target", "t", "console", "Indicates the type of output. Either console or clipboard.")
+	cmd.Flags().VarP(newListFlagValue("USDateTimeZ", &helpers.CmdHelpers.OutputFormatName), "output-format", "o", "The output format.  Use \"timeconverter show -f\" for a list of formats. Can be repeated or a comma separated list to output several formats. Custom formats can include a layout, like CustomGO=2006-01-02.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.OutputLayout, "output-layout", "r", "", "When output format is \"custom\" or \"customgo\", this is the layout text.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.OutputTemplateText, "output-template", "", "", "A Go text/template used to output the converted time instead of the output format, like \"backup-{{.DateOnly}}-{{.UnixSecs}}.tar\". Use \"timeconverter show --template-fields\" for the available fields.")
+	cmd.Flags().BoolVarP(&helpers.CmdHelpers.OutputValueOnly, "output-value-only", "v", false, "If true, only the converted value or critical errors will be sent to the output.")
+	cmd.Flags().VarP(newListFlagValue("", &helpers.CmdHelpers.OutputTimeZone), "output-timezone", "z", "A timezone to use when converting the output time.  If not specified, the local time will be used for the output time. Can be an IANA country/city ref or a timezone offset like -0700. Can be repeated or a comma separated list to output the time in several timezones.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.TruncateUnitName, "truncate", "", "", "Truncates the output time to a unit in the output timezone: second, minute, hour, day, week, month, quarter, year, or a duration like 15m.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.RoundUnitName, "round", "", "", "Rounds the output time to the nearest unit in the output timezone: second, minute, hour, day, week, month, quarter, year, or a duration like 15m.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.RelativeToValue, "relative-to", "", "", "The reference time for the Relative and RelativePrecise output formats, like \"2023-09-01T00:00:00Z\" or \"yesterday 09:00\". Its format is detected. If not specified, the current time is used.")
+	cmd.Flags().IntVarP(&helpers.CmdHelpers.UnixFloatPrecision, "precision", "", helpers.DefaultUnixFloatPrecision, "For the UnixSecsFloat, JulianDay, ModifiedJulianDay, RataDie and DecimalYear output formats, the number of decimal places, from 0 to 9.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.OutputTimeScaleName, "output-time-scale", "", "", "The time scale of output values: utc, tai or gps. Overrides --time-scale for output values.")
+}
+
+// listFlagValue is a string flag that can be repeated.  Repeated values are joined with commas, so
+// "-o UnixMilli -o RFC3339" results in the same value as "-o UnixMilli,RFC3339".
+type listFlagValue struct {
+	value   *string
+	changed bool
+}
+
+func newListFlagValue(defaultValue string, value *string) *listFlagValue {
+	*value = defaultValue
+	return &listFlagValue{value: value}
+}
+
+func (lfv *listFlagValue) String() string {
+	return *lfv.value
+}
+
+func (lfv *listFlagValue) Set(value string) error {
+	if lfv.changed {
+		*lfv.value += "," + value
+	} else {
+		*lfv.value = value
+		lfv.changed = true
+	}
+
+	return nil
+}
+
+func (lfv *listFlagValue) Type() string {
+	return "string"
+}
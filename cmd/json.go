@@ -0,0 +1,52 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package cmd
+
+import (
+	"github.com/hobysmith/timeconverter/converter"
+	"github.com/hobysmith/timeconverter/helpers"
+	"github.com/spf13/cobra"
+)
+
+// jsonCmd represents the json command
+var jsonCmd = &cobra.Command{
+	Use:   "json [file] [flags]",
+	Short: "Converts time values in JSON or JSON Lines at the indicated paths",
+	Long: `Converts time values in JSON or JSON Lines at the indicated paths.
+
+JSON is read from the file provided, or from piped input.  Each value found at a path is converted,
+and all other structure and key order are preserved.  Both string and numeric values are converted.
+Each JSON value is written out on its own line, so JSON Lines input produces JSON Lines output.`,
+	Example: `  cat incident.json | timeconverter json --path .created_at --path ".events[].ts" -i UnixMilli -o RFC3339
+  timeconverter json events.ndjson --path .ts -i RFC3339 -o UnixSecs
+  timeconverter json report.json --path ".items[0].closed" -i Auto -o USDateTimeZ --pretty`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConversion(func() error {
+			input, closeInput, err := openCommandInput(args, "JSON to convert")
+			if err != nil {
+				return err
+			}
+			defer closeInput()
+
+			tc := converter.New()
+			err = tc.ResolveFormats()
+			if err != nil {
+				return err
+			}
+
+			return tc.ConvertJSON(input, helpers.OP)
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(jsonCmd)
+	addConversionFlags(jsonCmd)
+	jsonCmd.Flags().StringArrayVarP(&helpers.CmdHelpers.JSONPaths, "path", "", nil, "The path of values to convert, like \".created_at\" or \".events[].ts\". Can be repeated.")
+	jsonCmd.Flags().BoolVarP(&helpers.CmdHelpers.JSONPretty, "pretty", "", false, "Outputs indented JSON rather than one compact value per line.")
+	jsonCmd.Flags().StringVarP(&helpers.CmdHelpers.BatchErrorModeName, "batch-errors", "", "abort", "How values that fail to convert are handled: abort, passthrough or marker.")
+	jsonCmd.Flags().BoolVarP(&helpers.CmdHelpers.PipeMode, "piped", "p", false, "[OPTIONAL] Explicitly indicates that you are piping input in from another app if auto-detection is not working.")
+}
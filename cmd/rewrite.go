@@ -0,0 +1,50 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package cmd
+
+import (
+	"github.com/hobysmith/timeconverter/converter"
+	"github.com/hobysmith/timeconverter/helpers"
+	"github.com/spf13/cobra"
+)
+
+// rewriteCmd represents the rewrite command
+var rewriteCmd = &cobra.Command{
+	Use:   "rewrite [file] [flags]",
+	Short: "Finds and converts time values embedded in text, like log lines",
+	Long: `Finds and converts time values embedded in text, like log lines.
+
+Text is read from the file provided, or from piped input.  Substrings matching the input format
+are converted to the output format, and the rest of the text is left untouched.  Use --pattern to
+provide a regex for finding values.  If the regex has a capture group, only the captured text is converted.`,
+	Example: `  cat app.log | timeconverter rewrite -i UnixMilli -o RFC3339
+  timeconverter rewrite app.log -i UnixSecs -o USDateTimeZ -z America/Chicago
+  timeconverter rewrite app.log --pattern "ts=(\d+)" -i UnixMilli -o RFC3339`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConversion(func() error {
+			input, closeInput, err := openCommandInput(args, "text to rewrite")
+			if err != nil {
+				return err
+			}
+			defer closeInput()
+
+			tc := converter.New()
+			err = tc.ResolveFormats()
+			if err != nil {
+				return err
+			}
+
+			return tc.RewriteText(input, helpers.OP)
+		})
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(rewriteCmd)
+	addConversionFlags(rewriteCmd)
+	rewriteCmd.Flags().StringVarP(&helpers.CmdHelpers.RewritePattern, "pattern", "x", "", "A regex for finding the values to convert.  If it has a capture group, only the captured text is converted.")
+	rewriteCmd.Flags().BoolVarP(&helpers.CmdHelpers.PipeMode, "piped", "p", false, "[OPTIONAL] Explicitly indicates that you are piping input in from another app if auto-detection is not working.")
+}
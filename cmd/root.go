@@ -25,6 +25,11 @@ var rootCmd = &cobra.Command{
 	Use:   "tc",
 	Short: "A utility for converting time values",
 	Long:  `A utility for converting time values`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// The shift has a default, so whether it was provided can only be told from the flag.  Flags that are
+		// not defined by the running command are never changed.
+		helpers.CmdHelpers.SnowflakeTimestampShiftProvided = cmd.Flags().Changed("snowflake-shift")
+	},
 	Args: func(cmd *cobra.Command, args []string) error {
 		if helpers.CheckIsPiped() {
 			return nil
@@ -64,14 +69,16 @@ var rootCmd = &cobra.Command{
 			}
 		}()
 
-		err = converter.New().Convert(false)
+		tc := converter.New()
+		err = tc.Convert(false)
 		if err != nil {
 			if helpers.ExitCode == helpers.ExitCodeSuccess {
 				// ExitCode was not set in Convert(), so use general exit code here
 				helpers.ExitCode = helpers.ExitCodeUnknownErrorInRootCommand
 			}
 
-			if !helpers.CmdHelpers.OutputValueOnly {
+			// with an output encoding, the error is written as a document instead of as plain text
+			if !tc.PrintEncodedError(err) && !helpers.CmdHelpers.OutputValueOnly {
 				fmt.Println(err)
 			}
 
@@ -110,6 +117,12 @@ func Execute() {
 		return
 	}
 
+	// Defaults are loaded here, rather than in init, so that every command has defined its flags
+	// before the loaded values are applied.  Flags parsed by Execute() still override the defaults.
+	if errInInit == nil {
+		helpers.CmdHelpers.LoadConfigIfExists()
+	}
+
 	err := rootCmd.Execute()
 	if err != nil {
 		helpers.ExitCode = helpers.ExitCodeErrorReturnedToExecute
@@ -121,29 +134,25 @@ func init() {
 	cmd.Use = "timeconverter dateTimeValue [flags]"
 	cmd.Example = `  timeconverter 681678000 --input-format UnixSecs
   timeconverter now --output-format USTimeStampZ
+  timeconverter 2023-09-02T10:21:24-05:00 --output-format UnixMilli
   timeconverter 681678000 --input-format UnixMilli --output-format RFC3339
   timeconverter 681678000000 --input-format UnixMilli --input-format secs --output-format RFC3339
   timeconverter 681678000000 --input-format UnixMilli --output-format custom --output-layout "mmm yyyy-mm-dd hhh:nn:ss.000 zthhmm""
   timeconverter 681678000000 --input-format uNIxmilLI --output-format customGo --output-layout "Jan 2006-01-02 15:04:05.000 Z-0700"
+  cat timestamps.txt | timeconverter --batch --input-format UnixSecs --output-format RFC3339
+  timeconverter 681678000 --output-format RFC3339,UnixMilli --output-encoding json
   timeconverter show --time-formats
   timeconverter show --custom-entities`
 
-	cmd.Flags().StringVarP(&helpers.CmdHelpers.OutputTargetName, "output-target", "t", "console", "Indicates the type of output. Either console or clipboard.")
-	cmd.Flags().StringVarP(&helpers.CmdHelpers.InputFormatName, "input-format", "i", "USDateTimeZ", "The input format. Use \"timeconverter show -f\" for a list of formats.")
-	cmd.Flags().StringVarP(&helpers.CmdHelpers.InputLayout, "input-layout", "l", "", "When input format is set to \"custom\" or \"customgo\", this is the layout text.")
-	cmd.Flags().StringVarP(&helpers.CmdHelpers.OutputFormatName, "output-format", "o", "USDateTimeZ", "The output format.  Use \"timeconverter show -f\" for a list of formats.")
-	cmd.Flags().StringVarP(&helpers.CmdHelpers.OutputLayout, "output-layout", "r", "", "When output format is \"custom\" or \"customgo\", this is the layout text.")
-	cmd.Flags().BoolVarP(&helpers.CmdHelpers.OutputValueOnly, "output-value-only", "v", false, "If true, only the converted value or critical errors will be sent to the output.")
+	addConversionFlags(cmd)
 	cmd.Flags().BoolVarP(&helpers.CmdHelpers.PipeMode, "piped", "p", false, "[OPTIONAL] Explicitly indicates that you are piping input in from another app if auto-detection is not working.")
+	cmd.Flags().BoolVarP(&helpers.CmdHelpers.BatchMode, "batch", "b", false, "Converts each line of the input independently, writing one result per line. Intended for piped input.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.BatchErrorModeName, "batch-errors", "", "abort", "In batch mode, how lines that fail to convert are handled: abort, passthrough or marker.")
+	cmd.Flags().StringVarP(&helpers.CmdHelpers.OutputEncodingName, "output-encoding", "", "text", "How the result is written: text, json or yaml. json and yaml write a document with the input, the results and any error.")
 	cmd.Flags().BoolVarP(&helpers.CmdHelpers.SetGlobalDefault, "set-global-default", "", false, "Global defaults will be created or updated from provided flags.")
 	cmd.Flags().BoolVarP(&helpers.CmdHelpers.SetDefault, "set-default", "", false, "Local defaults will be created or updated from provided flags.")
-	cmd.Flags().StringVarP(&helpers.CmdHelpers.OutputTimeZone, "output-timezone", "z", "", "A timezone to use when converting the output time.  If not specified, the local time will be used for the output time. Can be an IANA country/city ref or a timezone offset like -0700")
 
 	errInInit = helpers.LoadOutputPrinter()
-	if errInInit != nil {
-		return
-	}
-	helpers.CmdHelpers.LoadConfigIfExists()
 }
 
 func GetRootCmd() *cobra.Command {
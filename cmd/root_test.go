@@ -40,3 +40,58 @@ func TestSimpleConversion_FailsOnUnknownOutputFormat(t *testing.T) {
 	assert.NotNil(t, helpers.CmdHelpers.ErrResult)
 	assert.Contains(t, helpers.CmdHelpers.ErrResult.Error(), "Unknown output-format: Unknown")
 }
+
+func TestExplain_UsesSharedInputFlags(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		wantUTC       string
+		wantErrString string
+	}{
+		{
+			name:    "Snowflake",
+			args:    []string{"explain", "175928847299117063", "-i=Snowflake", "--snowflake=discord", "-z=+0000"},
+			wantUTC: "2016-04-30T11:18:25.796Z",
+		},
+		{
+			name:    "StrictISO8601",
+			args:    []string{"explain", "2023-09-02T10:21:24Z", "-i=ISO8601", "--strict", "-z=+0000"},
+			wantUTC: "2023-09-02T10:21:24Z",
+		},
+		{
+			name:          "StrictISO8601Rejected",
+			args:          []string{"explain", "2023-09-02T10:21:24+0000", "-i=ISO8601", "--strict", "-z=+0000"},
+			wantErrString: "Unable to parse",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.SnowflakeLayoutName = "twitter"
+		helpers.CmdHelpers.StrictISO8601 = false
+		helpers.CmdHelpers.OutputTimeZone = ""
+		helpers.CmdHelpers.OutputValueOnly = false
+		helpers.CmdHelpers.ErrResult = nil
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// earlier tests leave the output format set, which explain still validates
+			helpers.CmdHelpers.OutputFormatName = "USDateTimeZ"
+			helpers.CmdHelpers.ErrResult = nil
+
+			c := GetRootCmd()
+			c.SetArgs(test.args)
+			err := c.Execute()
+			assert.Nil(t, err)
+			if test.wantErrString != "" {
+				assert.NotNil(t, helpers.CmdHelpers.ErrResult)
+				assert.Contains(t, helpers.CmdHelpers.ErrResult.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, helpers.CmdHelpers.ErrResult)
+			assert.Contains(t, helpers.CmdHelpers.ConvertedResult, "UTC   : "+test.wantUTC)
+		})
+	}
+}
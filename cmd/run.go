@@ -0,0 +1,74 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/hobysmith/timeconverter/helpers"
+	"io"
+	"os"
+)
+
+// runConversion wraps the execution of commands that convert values, like rewrite.
+// It handles init failures, unloading the output printer, and reporting errors the same way that
+// the root command does.
+func runConversion(convert func() error) (err error) {
+	if errInInit != nil {
+		if helpers.ExitCode == helpers.ExitCodeSuccess {
+			// ExitCode was not set in LoadOutputPrinter(), so use general exit code here
+			helpers.ExitCode = helpers.ExitCodeUnknownErrorInRootCommand
+		}
+
+		return errInInit
+	}
+
+	defer func() {
+		outputErr := helpers.OP.UnloadOutputPrinter()
+		if outputErr != nil {
+			if err == nil {
+				err = outputErr
+			} else {
+				fmt.Printf("Error in UnloadOutputPrinter(): %s\n", err)
+			}
+		}
+	}()
+
+	err = convert()
+	if err != nil {
+		if helpers.ExitCode == helpers.ExitCodeSuccess {
+			// ExitCode was not set by the conversion, so use general exit code here
+			helpers.ExitCode = helpers.ExitCodeUnknownErrorInRootCommand
+		}
+
+		if !helpers.CmdHelpers.OutputValueOnly {
+			fmt.Println(err)
+		}
+
+		helpers.CmdHelpers.ErrResult = err
+		return nil
+	}
+
+	return nil
+}
+
+// openCommandInput returns the input for commands that accept an optional file name argument.
+// The file is used if provided, otherwise piped input is used.  The returned close func must be
+// called when the input is no longer needed.
+func openCommandInput(args []string, inputDesc string) (input io.Reader, closeInput func(), err error) {
+	switch {
+	case len(args) == 1:
+		inputFile, err := os.Open(args[0])
+		if err != nil {
+			helpers.ExitCode = helpers.ExitCodeFailureReadingPipeInput
+			return nil, nil, fmt.Errorf("Unable to open input file: %s", err)
+		}
+		return inputFile, func() { _ = inputFile.Close() }, nil
+	case helpers.CheckIsPiped():
+		return os.Stdin, func() {}, nil
+	default:
+		helpers.ExitCode = helpers.ExitCodeErrorNoInputProvided
+		return nil, nil, fmt.Errorf("No input provided. Provide a file name or pipe the %s.", inputDesc)
+	}
+}
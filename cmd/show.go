@@ -8,20 +8,23 @@ import (
 	"fmt"
 	"github.com/hobysmith/timeconverter/helpers"
 	"github.com/spf13/cobra"
+	"time"
 )
 
 var showTimeFormats bool
 var showCustomEntities bool
 var showLocalDefaults bool
 var showGlobalDefaults bool
+var showTemplateFields bool
+var showLeapSeconds bool
 
 // showCmd represents the show command
 var showCmd = &cobra.Command{
 	Use:   "show",
-	Short: "Will show time formats, custom text entity definitions, or defaults.",
-	Long:  "Will show time formats, custom text entity definitions, or defaults.",
+	Short: "Will show time formats, custom text entity definitions, output template fields, the leap second table, or defaults.",
+	Long:  "Will show time formats, custom text entity definitions, output template fields, the leap second table, or defaults.",
 	Run: func(cmd *cobra.Command, args []string) {
-		if !showTimeFormats && !showCustomEntities && !showLocalDefaults && !showGlobalDefaults {
+		if !showTimeFormats && !showCustomEntities && !showTemplateFields && !showLeapSeconds && !showLocalDefaults && !showGlobalDefaults {
 			_ = cmd.Help()
 			return
 		}
@@ -53,6 +56,14 @@ var showCmd = &cobra.Command{
 			printCustomEntities()
 		}
 
+		if showTemplateFields {
+			printTemplateFields()
+		}
+
+		if showLeapSeconds {
+			printLeapSeconds()
+		}
+
 		if showLocalDefaults {
 			printLocalDefaults()
 		}
@@ -67,6 +78,8 @@ func init() {
 	rootCmd.AddCommand(showCmd)
 	showCmd.Flags().BoolVarP(&showTimeFormats, "time-formats", "f", false, "Will show a list of the available time formats")
 	showCmd.Flags().BoolVarP(&showCustomEntities, "custom-entities", "c", false, "Will show a list of the available custom text entities")
+	showCmd.Flags().BoolVarP(&showTemplateFields, "template-fields", "m", false, "Will show a list of the fields available to output templates")
+	showCmd.Flags().BoolVarP(&showLeapSeconds, "leap-seconds", "", false, "Will show the built-in leap second table used for the TAI and GPS time scales")
 	showCmd.Flags().BoolVarP(&showLocalDefaults, "local-defaults", "l", false, "Will show the YAML for local default values")
 	showCmd.Flags().BoolVarP(&showGlobalDefaults, "global-defaults", "g", false, "Will show the YAML for global default values")
 }
@@ -114,6 +127,49 @@ Note: Time Format names are NOT case sensitive.
   UnixMilli          Unix Time in millisecond
   UnixMicro          Unix Time in microseconds
   UnixNano           Unix Time in nanoseconds
+  UnixSecsFloat      Unix Time in seconds with a decimal fraction, like 1693668084.123456. Use --precision to set
+                     the number of decimal places that are output
+  UnixSecsHex        Unix Time in seconds as hex, like 0x64F352F4. The 0x prefix is optional for input
+  UnixMilliHex       Unix Time in milliseconds as hex, like 0x18A567C099B
+  UnixMicroHex       Unix Time in microseconds as hex
+  UnixNanoHex        Unix Time in nanoseconds as hex
+  JulianDay          Julian Day, days since noon UTC on 4713 BC January 1, like 2460189.931527. Use --precision to
+                     set the number of decimal places that are output, for this and the formats below
+  ModifiedJulianDay  Modified Julian Day, days since 1858-11-17 UTC, like 60189.431527
+  RataDie            Rata Die, days since 0000-12-31 UTC, so 0001-01-01 is day 1, like 738765.431527
+  DecimalYear        The year with the time as a fraction of the year in UTC, like 2023.669675
+  FileTime           Windows FILETIME, 100ns intervals since 1601-01-01 UTC, like 133381236840000000
+  FileTimeHex        Windows FILETIME as 16 hex digits, like 0x01D9DD87398CEA00. The 0x prefix is optional for input
+  DotNetTicks        .NET DateTime.Ticks, 100ns intervals since 0001-01-01 UTC, like 638292468840000000
+  Excel1900          Excel serial date in the 1900 date system, days since 1899-12-30 with the time as a fraction,
+                     like 45171.5. Includes the Lotus 1-2-3 leap year bug, so serial 60 is rejected
+  Excel1904          Excel serial date in the 1904 date system, days since 1904-01-01, like 43709.5
+  Cocoa              Cocoa and Core Data absolute time, seconds since 2001-01-01 UTC, like 715342884.25
+  WebKit             WebKit and Chrome time, microseconds since 1601-01-01 UTC, like 13338123684000000
+  NTP                NTP timestamp, seconds since 1900-01-01 UTC with a decimal fraction, like 3902638884.25
+  NTPHex             NTP 64-bit timestamp as hex seconds and fraction, like E89D8B24.40000000
+  GPS                GPS week and seconds of week, like 2277:555702. Uses the leap second table for the GPS-UTC offset
+  ISO8601            Lenient ISO 8601 input, like "2023-09-02 10:21,5+05" or "20230902T1021Z". Use --strict for RFC 3339 only.
+                     Output is "2006-01-02T15:04:05.999999999Z07:00"
+  ISOWeekDate        ISO 8601 week date, like "2023-W35-6". The year is the ISO week-numbering year
+  ISOOrdinalDate     ISO 8601 ordinal date, the year and day of year, like "2023-244"
+  ISOBasic           "20060102T150405Z0700"
+  UUID               Input only. The time in a version 1, 6 or 7 UUID, like 017f22e2-79b0-7cc3-98c4-dc0c0c07398f
+  ULID               Input only. The time in a ULID, like 01ARZ3NDEKTSV4RRFFQ69G5FAV
+  KSUID              Input only. The time in a KSUID, like 0ujtsYcgvSTl8PAuAdqWYSMnLOv
+  ObjectID           Input only. The time in a MongoDB ObjectID, like 507f1f77bcf86cd799439011
+  Snowflake          Input only. The time in a Snowflake ID, like 1541815603606036480. Use --snowflake to choose the
+                     layout: twitter, discord, instagram or custom
+  UUIDv7Min          Output only. The lowest UUIDv7 for the time's millisecond, like 017f22e2-79b0-7000-8000-000000000000
+  UUIDv7Max          Output only. The highest UUIDv7 for the time's millisecond, like 017f22e2-79b0-7fff-bfff-ffffffffffff
+  ULIDMin            Output only. The lowest ULID for the time's millisecond, like 01FWHE4YDG0000000000000000
+  ULIDMax            Output only. The highest ULID for the time's millisecond, like 01FWHE4YDGZZZZZZZZZZZZZZZZ
+  SnowflakeMin       Output only. The lowest Snowflake ID for the time's millisecond, using the --snowflake layout
+  SnowflakeMax       Output only. The highest Snowflake ID for the time's millisecond, using the --snowflake layout
+  Relative           Output only. The time relative to now or --relative-to, using the largest unit, like "3 hours ago"
+  RelativePrecise    Output only. The time relative to now or --relative-to, using all units, like "2d 4h 13m ago"
+  Auto               Input only. Detects the format from the input value. Digit-only values are read as Unix time,
+                     using seconds, millis, micros or nanos based on the number of digits
   Custom             Provide layout text using the flags "--output-layout" and "input-layout" in Timeconverter's formatting syntax
   CustomGO           Provide layout text using the flags "--output-layout" and "input-layout" in Go's fmt formatting syntax
                      See https://pkg.go.dev/time#pkg-constants
@@ -137,6 +193,45 @@ Note: Entities references are NOT case sensitive.
 	helpers.OP.Print(helpers.OutputMode_Force, "")
 }
 
+func printTemplateFields() {
+	helpers.OP.Print(helpers.OutputMode_Force, `
+Note: Template field names ARE case sensitive.
+
+  TimeConverter Output Template Fields
+
+  Field                Description
+  ===============      ==================================================================================`)
+
+	for _, desc := range helpers.TemplateFieldToDescription {
+		helpers.OP.Print(helpers.OutputMode_Force, fmt.Sprintf(
+			"  %-15s      %s", desc.Name, desc.Description))
+	}
+
+	helpers.OP.Print(helpers.OutputMode_Force, `
+  Every time format name, other than Auto, Custom and CustomGO, is also a field with the time rendered in that
+  format, like {{.RFC3339}} or {{.DateOnly}}.  The Unix fields are numbers.
+`)
+}
+
+func printLeapSeconds() {
+	helpers.OP.Printf(helpers.OutputMode_Force, `
+  TimeConverter Leap Second Table, version %s
+
+  UTC Start       TAI - UTC
+  ===========     =========
+`, helpers.LeapSecondTableVersion)
+
+	for _, leapSecond := range helpers.LeapSeconds() {
+		helpers.OP.Print(helpers.OutputMode_Force, fmt.Sprintf(
+			"  %-11s     %3ds", leapSecond.UTCStart.Format(time.DateOnly), leapSecond.TAIOffset))
+	}
+
+	helpers.OP.Print(helpers.OutputMode_Force, `
+  Each entry after the first follows a leap second, 23:59:60 UTC on the day before.  GPS time is 19 seconds
+  behind TAI.
+`)
+}
+
 func printLocalDefaults() {
 	exists, content, err := helpers.CmdHelpers.GetLocalConfigDataIfExists()
 	if err != nil {
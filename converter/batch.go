@@ -0,0 +1,98 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package converter
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/hobysmith/timeconverter/helpers"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxBatchLineSize is the longest input line that batch mode will accept
+const maxBatchLineSize = 1024 * 1024
+
+// convertBatch sets up the input and output streams for batch mode, then hands off to ConvertLines.
+// Piped input is read from stdin.  Otherwise, the provided value is treated as the batch, which
+// allows a multi-line value to be passed on the commandline.
+func (tfd *TimeConverter) convertBatch(fullQuiet bool) error {
+	var input io.Reader = os.Stdin
+	if !helpers.CmdHelpers.PipeMode {
+		input = strings.NewReader(helpers.CmdHelpers.Value)
+	}
+
+	var output io.Writer = helpers.OP
+	if fullQuiet {
+		output = io.Discard
+	}
+
+	return tfd.ConvertLines(input, output)
+}
+
+// ConvertLines streams the input line by line, converting each line independently and writing
+// the results to output in the same order.  Blank lines are written back out as blank lines, so that
+// output lines always correspond to input lines.
+//
+// Lines that fail to convert are handled according to CmdHelpers.BatchErrorMode.  They will either abort
+// the batch, be passed through unchanged, or be replaced with BatchErrorMarker.
+// ResolveFormats must be called before calling ConvertLines.
+func (tfd *TimeConverter) ConvertLines(input io.Reader, output io.Writer) error {
+	if err := tfd.requireSingleOutputFormat(); err != nil {
+		return err
+	}
+
+	batchErrorMode, found := helpers.BatchErrorModeNameToMode[strings.ToLower(helpers.CmdHelpers.BatchErrorModeName)]
+	if !found {
+		return fmt.Errorf("Unknown batch-errors mode: %s", helpers.CmdHelpers.BatchErrorModeName)
+	}
+
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBatchLineSize)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		lineText := scanner.Text()
+
+		inputVal := strings.Trim(lineText, "\r\t ")
+		if inputVal == "" {
+			if _, err := fmt.Fprintln(output); err != nil {
+				return err
+			}
+			continue
+		}
+
+		convertedResult, err := tfd.ConvertValue(inputVal)
+		if err != nil {
+			switch batchErrorMode {
+			case helpers.BatchErrorMode_PassThrough:
+				convertedResult = strings.TrimRight(lineText, "\r")
+			case helpers.BatchErrorMode_Marker:
+				convertedResult = helpers.BatchErrorMarker
+			default:
+				if helpers.ExitCode == helpers.ExitCodeSuccess {
+					helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+				}
+				return fmt.Errorf("Line %d: %s", lineNum, err)
+			}
+
+			// The failure was handled as requested, so it should not fail the whole run
+			helpers.ExitCode = helpers.ExitCodeSuccess
+		}
+
+		if _, err = fmt.Fprintln(output, convertedResult); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		helpers.ExitCode = helpers.ExitCodeFailureReadingPipeInput
+		return fmt.Errorf("Failure reading batch input: %s", err)
+	}
+
+	return nil
+}
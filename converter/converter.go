@@ -14,11 +14,19 @@ import (
 	"fmt"
 	"github.com/hobysmith/timeconverter/helpers"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 )
 
+// leapSecondRegex matches the minute and second of a leap second in a time value, like the ":59:60" in
+// "2016-12-31T23:59:60Z".  Values can be in any timezone, including offsets that are not whole hours, so the hour
+// is not matched and any minute is.  Matches that are not real leap seconds are rejected by IsLeapSecondAt.  The
+// minute and the character that follows are captured, so that they are kept when the leap second is replaced.
+var leapSecondRegex = regexp.MustCompile(`(:\d{2}):60($|\D)`)
+
 // TypeConverter is the primary wrapper for conversion functionality.
 type TimeConverter struct{}
 
@@ -30,6 +38,21 @@ func New() *TimeConverter {
 // Note that the parameter fullQuiet means NOTHING should be output from this app,
 // which should generally only be used by test funcs.
 func (tfd *TimeConverter) Convert(fullQuiet bool) (err error) {
+	// the encoding is resolved first, so that any errors that follow can be reported in that encoding
+	err = tfd.resolveOutputEncoding()
+	if err != nil {
+		return err
+	}
+
+	err = tfd.ResolveFormats()
+	if err != nil {
+		return err
+	}
+
+	if helpers.CmdHelpers.BatchMode {
+		return tfd.convertBatch(fullQuiet)
+	}
+
 	// Make sure that only 1 input value has been provided
 	var inputVal string
 
@@ -40,6 +63,7 @@ func (tfd *TimeConverter) Convert(fullQuiet bool) (err error) {
 			return fmt.Errorf("Failure reading pipe input: %s", err)
 		}
 		inputVal = strings.Trim(string(inputValBytes), "\n\r\t ")
+		helpers.CmdHelpers.Value = inputVal
 	} else {
 		inputVal = helpers.CmdHelpers.Value
 	}
@@ -49,65 +73,574 @@ func (tfd *TimeConverter) Convert(fullQuiet bool) (err error) {
 		return errors.New("No input provided")
 	}
 
+	inputTime, err := tfd.ParseAndOffsetValue(inputVal)
+	if err != nil {
+		return err
+	}
+
+	// without an output timezone, the time is output in the location it was read in
+	outputTimeZones := helpers.CmdHelpers.OutputTimeZones
+	if len(outputTimeZones) == 0 {
+		outputTimeZones = []string{""}
+	}
+
+	var zoneTimes []time.Time
+	helpers.CmdHelpers.ConvertedResults = nil
+	for _, outputTimeZone := range outputTimeZones {
+		convertedTime, err := tfd.AdjustOutputTime(inputTime, outputTimeZone)
+		if err != nil {
+			return err
+		}
+		zoneTimes = append(zoneTimes, convertedTime)
+
+		for _, outputFormat := range helpers.CmdHelpers.OutputFormats {
+			convertedResult, err := tfd.FormatOutputTime(convertedTime, outputFormat)
+			if err != nil {
+				return err
+			}
+			helpers.CmdHelpers.ConvertedResults = append(helpers.CmdHelpers.ConvertedResults, convertedResult)
+		}
+	}
+	helpers.CmdHelpers.ConvertedResult = helpers.CmdHelpers.ConvertedResults[0]
+
+	if fullQuiet {
+		// fullQuiet means NOTHING should be output, which is generally only used by test funcs
+		return
+	}
+
+	if helpers.CmdHelpers.OutputEncoding != helpers.OutputEncoding_Text {
+		return tfd.printConversionDocument(BuildConversionDocument(inputVal, zoneTimes))
+	}
+
+	if helpers.CmdHelpers.OutputValueOnly {
+		for _, convertedResult := range helpers.CmdHelpers.ConvertedResults {
+			helpers.OP.Printf(helpers.OutputMode_Force, "%s\n", convertedResult)
+		}
+	} else {
+		if helpers.CmdHelpers.InputFormat == helpers.TimeFormat_Auto &&
+			helpers.CmdHelpers.DetectedInputFormat != helpers.TimeFormat_Auto {
+			helpers.OP.Printf(
+				helpers.OutputMode_Verbose,
+				"Detected Input Format: %s\n",
+				helpers.TimeFormatToName[helpers.CmdHelpers.DetectedInputFormat],
+			)
+		}
+
+		switch {
+		case len(zoneTimes) > 1:
+			tfd.printWorldClock(zoneTimes)
+		case len(helpers.CmdHelpers.ConvertedResults) == 1:
+			helpers.OP.Printf(helpers.OutputMode_Force, "Converted Result: %s\n", helpers.CmdHelpers.ConvertedResult)
+		default:
+			tfd.printLabeledResults()
+		}
+	}
+
+	return nil
+}
+
+// printLabeledResults outputs each converted result on its own line, labeled with its output format
+func (tfd *TimeConverter) printLabeledResults() {
+	labelWidth := 0
+	for _, outputFormat := range helpers.CmdHelpers.OutputFormats {
+		if len(outputFormat.Desc()) > labelWidth {
+			labelWidth = len(outputFormat.Desc())
+		}
+	}
+
+	helpers.OP.Printf(helpers.OutputMode_Force, "Converted Results:\n")
+	for resultIdx, outputFormat := range helpers.CmdHelpers.OutputFormats {
+		helpers.OP.Printf(
+			helpers.OutputMode_Force,
+			"  %-*s : %s\n",
+			labelWidth,
+			outputFormat.Desc(),
+			helpers.CmdHelpers.ConvertedResults[resultIdx],
+		)
+	}
+}
+
+// printWorldClock outputs a row for each output timezone, with the zone name, its offset, whether daylight
+// savings is in effect, and the converted results for each output format
+func (tfd *TimeConverter) printWorldClock(zoneTimes []time.Time) {
+	tableBuilder := new(strings.Builder)
+	tableWriter := tabwriter.NewWriter(tableBuilder, 0, 0, 2, ' ', 0)
+
+	headers := []string{"Timezone", "Offset", "DST"}
+	for _, outputFormat := range helpers.CmdHelpers.OutputFormats {
+		headers = append(headers, outputFormat.Desc())
+	}
+	fmt.Fprintf(tableWriter, "  %s\n", strings.Join(headers, "\t"))
+
+	formatCount := len(helpers.CmdHelpers.OutputFormats)
+	for zoneIdx, zoneTime := range zoneTimes {
+		isDST := "no"
+		if zoneTime.IsDST() {
+			isDST = "yes"
+		}
+
+		row := []string{helpers.CmdHelpers.OutputTimeZones[zoneIdx], zoneTime.Format("-0700"), isDST}
+		row = append(row, helpers.CmdHelpers.ConvertedResults[zoneIdx*formatCount:(zoneIdx+1)*formatCount]...)
+		fmt.Fprintf(tableWriter, "  %s\n", strings.Join(row, "\t"))
+	}
+	_ = tableWriter.Flush()
+
+	helpers.OP.Printf(helpers.OutputMode_Force, "Converted Results:\n%s", tableBuilder.String())
+}
+
+// ResolveFormats maps the input and output format names to their TimeFormat types.
+// When a name is not provided, the input format defaults to Auto and the output format defaults to USDateTimeZ.
+// The output format name can be a comma separated list, which is resolved into CmdHelpers.OutputFormats.
+// CmdHelpers.OutputFormat is set to the first output format.  If an output template is provided, it replaces the output format.
+// The input and output timezones, time scales, month-end mode, add/subtract durations, truncate/round units and
+// snowflake layout are also resolved here, so that bad values are reported up front rather than as a parse failure.
+func (tfd *TimeConverter) ResolveFormats() error {
+	if _, err := helpers.CmdHelpers.InputLocation(); err != nil {
+		return err
+	}
+
+	if err := tfd.resolveOutputTimeZones(); err != nil {
+		return err
+	}
+
+	if err := tfd.resolveTimeScales(); err != nil {
+		return err
+	}
+
+	if err := tfd.resolveTimeOffsets(); err != nil {
+		return err
+	}
+
+	if err := tfd.resolveTimeBoundaries(); err != nil {
+		return err
+	}
+
 	if helpers.CmdHelpers.InputFormatName == "" {
-		helpers.CmdHelpers.InputFormat = helpers.TimeFormat_USDateTimeZ
+		helpers.CmdHelpers.InputFormat = helpers.TimeFormat_Auto
 	} else {
-		found := false
-		helpers.CmdHelpers.InputFormat, found = helpers.NameToTimeFormat[strings.ToUpper(helpers.CmdHelpers.InputFormatName)]
-		if !found {
-			return fmt.Errorf("Unknown input-format: %s", helpers.CmdHelpers.InputFormatName)
+		inputFormat, err := resolveInputFormatName(helpers.CmdHelpers.InputFormatName, "input-format")
+		if err != nil {
+			return err
 		}
+
+		helpers.CmdHelpers.InputFormat = inputFormat
+	}
+
+	if err := tfd.resolveSnowflakeLayout(); err != nil {
+		return err
+	}
+
+	if err := tfd.resolveRelativeTo(); err != nil {
+		return err
 	}
 
 	if helpers.CmdHelpers.OutputFormatName == "" {
-		helpers.CmdHelpers.OutputFormat = helpers.TimeFormat_USDateTimeZ
+		helpers.CmdHelpers.OutputFormats = []helpers.OutputFormatSpec{{Format: helpers.TimeFormat_USDateTimeZ}}
+	} else {
+		var err error
+		helpers.CmdHelpers.OutputFormats, err = helpers.ParseOutputFormatList(
+			helpers.CmdHelpers.OutputFormatName,
+			helpers.CmdHelpers.OutputLayout,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	helpers.CmdHelpers.OutputFormat = helpers.CmdHelpers.OutputFormats[0].Format
+
+	if helpers.CmdHelpers.UnixFloatPrecision < 0 || helpers.CmdHelpers.UnixFloatPrecision > 9 {
+		return fmt.Errorf("Invalid precision value: %d. Expected 0 to 9", helpers.CmdHelpers.UnixFloatPrecision)
+	}
+
+	return tfd.resolveOutputTemplate()
+}
+
+// resolveInputFormatName returns the TimeFormat for an input format name.  Output only formats, like Relative, are
+// rejected.  The flagName is the flag the name came from, and is used for error messages.
+func resolveInputFormatName(formatName string, flagName string) (helpers.TimeFormat, error) {
+	inputFormat, found := helpers.NameToTimeFormat[strings.ToUpper(formatName)]
+	if !found {
+		return helpers.TimeFormat_Auto, fmt.Errorf("Unknown %s: %s", flagName, formatName)
+	}
+
+	if helpers.IsOutputOnlyTimeFormat(inputFormat) {
+		return helpers.TimeFormat_Auto, fmt.Errorf("%s is only supported as an output format", helpers.TimeFormatToName[inputFormat])
+	}
+
+	return inputFormat, nil
+}
+
+// resolveSnowflakeLayout resolves CmdHelpers.SnowflakeLayout from the snowflake preset name.  The custom layout
+// uses CmdHelpers.SnowflakeEpochMillis and CmdHelpers.SnowflakeTimestampShift instead.
+func (tfd *TimeConverter) resolveSnowflakeLayout() error {
+	layoutName := strings.ToLower(helpers.CmdHelpers.SnowflakeLayoutName)
+	if layoutName == "" {
+		layoutName = "twitter"
+	}
+
+	if layoutName != helpers.SnowflakeLayoutName_Custom {
+		if helpers.CmdHelpers.SnowflakeEpochMillis != "" {
+			return errors.New("A snowflake-epoch value is only used with the custom snowflake layout")
+		}
+
+		if helpers.CmdHelpers.SnowflakeTimestampShiftProvided {
+			return errors.New("A snowflake-shift value is only used with the custom snowflake layout")
+		}
+
+		layout, found := helpers.SnowflakeLayoutNameToLayout[layoutName]
+		if !found {
+			return fmt.Errorf("Unknown snowflake layout: %s", helpers.CmdHelpers.SnowflakeLayoutName)
+		}
+
+		helpers.CmdHelpers.SnowflakeLayout = layout
+		return nil
+	}
+
+	if helpers.CmdHelpers.SnowflakeEpochMillis == "" {
+		return errors.New("The custom snowflake layout requires a snowflake-epoch value, in Unix milliseconds")
+	}
+
+	epochMillis, err := strconv.ParseInt(helpers.CmdHelpers.SnowflakeEpochMillis, 10, 64)
+	if err != nil {
+		return fmt.Errorf("Invalid snowflake-epoch value: %s. Expected Unix milliseconds, like 1288834974657", helpers.CmdHelpers.SnowflakeEpochMillis)
+	}
+
+	if helpers.CmdHelpers.SnowflakeTimestampShift < 0 || helpers.CmdHelpers.SnowflakeTimestampShift > 63 {
+		return fmt.Errorf("Invalid snowflake-shift value: %d. Expected 0 to 63", helpers.CmdHelpers.SnowflakeTimestampShift)
+	}
+
+	helpers.CmdHelpers.SnowflakeLayout = helpers.SnowflakeLayout{
+		EpochMillis:    epochMillis,
+		TimestampShift: uint(helpers.CmdHelpers.SnowflakeTimestampShift),
+	}
+	return nil
+}
+
+// resolveRelativeTo parses CmdHelpers.RelativeToValue into CmdHelpers.RelativeTo, which is the reference time for the
+// relative output formats.  The value's format is detected, so it can be a relative expression like "now-1d".
+func (tfd *TimeConverter) resolveRelativeTo() error {
+	helpers.CmdHelpers.RelativeTo = time.Time{}
+	if helpers.CmdHelpers.RelativeToValue == "" {
+		return nil
+	}
+
+	relativeTo, err := tfd.parseValueWithFormat(helpers.CmdHelpers.RelativeToValue, helpers.TimeFormat_Auto, "")
+	if err != nil {
+		return fmt.Errorf("Invalid relative-to value: %s", err)
+	}
+
+	helpers.CmdHelpers.RelativeTo = relativeTo
+	return nil
+}
+
+// resolveOutputTemplate parses CmdHelpers.OutputTemplateText, and replaces the output format with the template.
+// A template can't be combined with several output formats, since the template can already render any of them.
+func (tfd *TimeConverter) resolveOutputTemplate() error {
+	if helpers.CmdHelpers.OutputTemplateText == "" {
+		return nil
+	}
+
+	if len(helpers.CmdHelpers.OutputFormats) > 1 {
+		return errors.New("Use either multiple output formats or an output template, not both")
+	}
+
+	outputTemplate, err := helpers.ParseOutputTemplate(helpers.CmdHelpers.OutputTemplateText)
+	if err != nil {
+		return err
+	}
+
+	helpers.CmdHelpers.OutputFormats[0].Layout = helpers.CmdHelpers.OutputTemplateText
+	helpers.CmdHelpers.OutputFormats[0].Template = outputTemplate
+	return nil
+}
+
+// resolveOutputTimeZones splits the comma separated list in CmdHelpers.OutputTimeZone into CmdHelpers.OutputTimeZones,
+// and makes sure that each zone can be loaded
+func (tfd *TimeConverter) resolveOutputTimeZones() error {
+	helpers.CmdHelpers.OutputTimeZones = nil
+
+	for _, outputTimeZone := range strings.Split(helpers.CmdHelpers.OutputTimeZone, ",") {
+		outputTimeZone = strings.TrimSpace(outputTimeZone)
+		if outputTimeZone == "" {
+			continue
+		}
+
+		if _, err := helpers.LoadTimeZone(outputTimeZone, "output"); err != nil {
+			return err
+		}
+		helpers.CmdHelpers.OutputTimeZones = append(helpers.CmdHelpers.OutputTimeZones, outputTimeZone)
+	}
+
+	return nil
+}
+
+// resolveTimeScales resolves the input and output time scales.  The input-time-scale and output-time-scale values
+// override the time-scale value, and the time scale defaults to utc.
+func (tfd *TimeConverter) resolveTimeScales() error {
+	var err error
+	helpers.CmdHelpers.InputTimeScale, err = resolveTimeScale(helpers.CmdHelpers.InputTimeScaleName)
+	if err != nil {
+		return err
+	}
+
+	helpers.CmdHelpers.OutputTimeScale, err = resolveTimeScale(helpers.CmdHelpers.OutputTimeScaleName)
+	return err
+}
+
+// resolveTimeScale returns the TimeScale for scaleName, or for CmdHelpers.TimeScaleName if scaleName is empty
+func resolveTimeScale(scaleName string) (helpers.TimeScale, error) {
+	if scaleName == "" {
+		scaleName = helpers.CmdHelpers.TimeScaleName
+	}
+
+	if scaleName == "" {
+		return helpers.TimeScale_UTC, nil
+	}
+
+	timeScale, found := helpers.TimeScaleNameToScale[strings.ToLower(scaleName)]
+	if !found {
+		return helpers.TimeScale_UTC, fmt.Errorf("Unknown time scale: %s. Expected utc, tai or gps", scaleName)
+	}
+
+	return timeScale, nil
+}
+
+// resolveTimeOffsets resolves the month-end mode, and parses the add and subtract durations into CmdHelpers.TimeOffsets
+func (tfd *TimeConverter) resolveTimeOffsets() error {
+	if helpers.CmdHelpers.MonthEndModeName == "" {
+		helpers.CmdHelpers.MonthEndMode = helpers.MonthEndMode_Clamp
 	} else {
 		found := false
-		helpers.CmdHelpers.OutputFormat, found = helpers.NameToTimeFormat[strings.ToUpper(helpers.CmdHelpers.OutputFormatName)]
+		helpers.CmdHelpers.MonthEndMode, found = helpers.MonthEndModeNameToMode[strings.ToLower(helpers.CmdHelpers.MonthEndModeName)]
 		if !found {
-			return fmt.Errorf("Unknown output-format: %s", helpers.CmdHelpers.OutputFormatName)
+			return fmt.Errorf("Unknown month-end mode: %s", helpers.CmdHelpers.MonthEndModeName)
 		}
 	}
 
-	var convertedTime time.Time
+	helpers.CmdHelpers.TimeOffsets = nil
 
-	if strings.ToLower(inputVal) == "now" {
-		convertedTime = time.Now()
+	if helpers.CmdHelpers.AddDuration != "" {
+		addOffset, err := helpers.ParseCalendarDuration(helpers.CmdHelpers.AddDuration)
+		if err != nil {
+			return fmt.Errorf("Invalid add value: %s", err)
+		}
+		helpers.CmdHelpers.TimeOffsets = append(helpers.CmdHelpers.TimeOffsets, addOffset)
+	}
+
+	if helpers.CmdHelpers.SubtractDuration != "" {
+		subtractOffset, err := helpers.ParseCalendarDuration(helpers.CmdHelpers.SubtractDuration)
+		if err != nil {
+			return fmt.Errorf("Invalid subtract value: %s", err)
+		}
+		helpers.CmdHelpers.TimeOffsets = append(helpers.CmdHelpers.TimeOffsets, subtractOffset.Negate())
+	}
+
+	return nil
+}
+
+// resolveTimeBoundaries resolves the week start, and the truncate or round units into CmdHelpers.TruncateBoundary
+// and CmdHelpers.RoundBoundary
+func (tfd *TimeConverter) resolveTimeBoundaries() error {
+	if helpers.CmdHelpers.WeekStartName == "" {
+		helpers.CmdHelpers.WeekStart = time.Monday
 	} else {
-		convertedTime, err = tfd.ParseInputTime(inputVal, helpers.CmdHelpers.InputFormat)
+		found := false
+		helpers.CmdHelpers.WeekStart, found = helpers.WeekdayNameToWeekday[strings.ToLower(helpers.CmdHelpers.WeekStartName)]
+		if !found {
+			return fmt.Errorf("Unknown week-start day: %s", helpers.CmdHelpers.WeekStartName)
+		}
+	}
+
+	helpers.CmdHelpers.TruncateBoundary = nil
+	helpers.CmdHelpers.RoundBoundary = nil
+
+	if helpers.CmdHelpers.TruncateUnitName != "" && helpers.CmdHelpers.RoundUnitName != "" {
+		return errors.New("Use either truncate or round, not both")
+	}
+
+	if helpers.CmdHelpers.TruncateUnitName != "" {
+		truncateBoundary, err := helpers.ParseTimeBoundary(helpers.CmdHelpers.TruncateUnitName, helpers.CmdHelpers.WeekStart)
 		if err != nil {
-			return fmt.Errorf(
-				"Unable to parse \"%s\" using format %s. Input value or format is not correct.",
-				inputVal,
-				helpers.CmdHelpers.InputFormatDesc(),
-			)
+			return fmt.Errorf("Invalid truncate value: %s", err)
 		}
+		helpers.CmdHelpers.TruncateBoundary = &truncateBoundary
 	}
 
-	if helpers.CmdHelpers.OutputTimeZone != "" {
-		convertedTime, err = helpers.AdjustForOutputTimeZone(convertedTime)
+	if helpers.CmdHelpers.RoundUnitName != "" {
+		roundBoundary, err := helpers.ParseTimeBoundary(helpers.CmdHelpers.RoundUnitName, helpers.CmdHelpers.WeekStart)
 		if err != nil {
-			return err
+			return fmt.Errorf("Invalid round value: %s", err)
 		}
+		helpers.CmdHelpers.RoundBoundary = &roundBoundary
+	}
+
+	return nil
+}
+
+// requireSingleOutputFormat returns an error if more than one output format or output timezone was provided.  This is
+// used by conversions that replace each value in place, like batch mode, where there is only room for one result.
+func (tfd *TimeConverter) requireSingleOutputFormat() error {
+	if len(helpers.CmdHelpers.OutputFormats) > 1 {
+		return errors.New("Multiple output formats are only supported when converting a single value")
 	}
 
-	helpers.CmdHelpers.ConvertedResult, err = helpers.NewDateTimeFormatter(convertedTime).FormatDateTime(helpers.CmdHelpers.OutputFormat)
+	if len(helpers.CmdHelpers.OutputTimeZones) > 1 {
+		return errors.New("Multiple output timezones are only supported when converting a single value")
+	}
+
+	return nil
+}
+
+// ConvertValue converts a single input value with ConvertValueToTime, and returns the value formatted
+// in the first resolved output format.
+// ResolveFormats must be called before calling ConvertValue.
+func (tfd *TimeConverter) ConvertValue(inputVal string) (convertedResult string, err error) {
+	convertedTime, err := tfd.ConvertValueToTime(inputVal)
 	if err != nil {
-		return fmt.Errorf("Critical error: Failure converting input to formatted result: %s", err)
+		return "", err
 	}
 
-	if fullQuiet {
-		// fullQuiet means NOTHING should be output, which is generally only used by test funcs
-		return
+	return tfd.FormatOutputTime(convertedTime, helpers.CmdHelpers.OutputFormats[0])
+}
+
+// FormatOutputTime formats the converted time in the indicated output format, on the output time scale's clock.
+// The GPS format always uses the GPS time scale, so the output time scale does not apply to it.
+func (tfd *TimeConverter) FormatOutputTime(convertedTime time.Time, outputFormat helpers.OutputFormatSpec) (string, error) {
+	if outputFormat.Template != nil || outputFormat.Format != helpers.TimeFormat_GPS {
+		convertedTime = helpers.UTCToTimeScale(convertedTime, helpers.CmdHelpers.OutputTimeScale, helpers.CmdHelpers.InputLeapSecond)
 	}
 
-	if helpers.CmdHelpers.OutputValueOnly {
-		helpers.OP.Printf(helpers.OutputMode_Force, "%s\n", helpers.CmdHelpers.ConvertedResult)
-	} else {
-		helpers.OP.Printf(helpers.OutputMode_Force, "Converted Result: %s\n", helpers.CmdHelpers.ConvertedResult)
+	if outputFormat.Template != nil {
+		return helpers.NewDateTimeFormatter(convertedTime).FormatTemplate(outputFormat.Template)
 	}
 
-	return nil
+	convertedResult, err := helpers.NewDateTimeFormatter(convertedTime).FormatDateTimeWithLayout(outputFormat.Format, outputFormat.Layout)
+	if err != nil {
+		return "", fmt.Errorf("Critical error: Failure converting input to formatted result: %s", err)
+	}
+
+	return convertedResult, nil
+}
+
+// ConvertValueToTime parses a single input value using the resolved input format, applies any add or subtract
+// offsets and the first output timezone, then truncates or rounds the time in the output timezone.
+// ResolveFormats must be called before calling ConvertValueToTime.
+func (tfd *TimeConverter) ConvertValueToTime(inputVal string) (convertedTime time.Time, err error) {
+	convertedTime, err = tfd.ParseAndOffsetValue(inputVal)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	outputTimeZone := ""
+	if len(helpers.CmdHelpers.OutputTimeZones) > 0 {
+		outputTimeZone = helpers.CmdHelpers.OutputTimeZones[0]
+	}
+
+	return tfd.AdjustOutputTime(convertedTime, outputTimeZone)
+}
+
+// ParseAndOffsetValue parses a single input value using the resolved input format, and applies any add
+// or subtract offsets
+func (tfd *TimeConverter) ParseAndOffsetValue(inputVal string) (convertedTime time.Time, err error) {
+	convertedTime, err = tfd.ParseInputValue(inputVal)
+	if err != nil {
+		if helpers.CmdHelpers.InputFormat == helpers.TimeFormat_Auto {
+			// detection errors already describe the candidates, or the lack of them
+			return time.Time{}, err
+		}
+
+		return time.Time{}, fmt.Errorf(
+			"Unable to parse \"%s\" using format %s. Input value or format is not correct.",
+			inputVal,
+			helpers.CmdHelpers.InputFormatDesc(),
+		)
+	}
+
+	for _, timeOffset := range helpers.CmdHelpers.TimeOffsets {
+		convertedTime = timeOffset.AddTo(convertedTime, helpers.CmdHelpers.MonthEndMode)
+		// an offset from a leap second lands on an ordinary second
+		helpers.CmdHelpers.InputLeapSecond = false
+	}
+
+	return convertedTime, nil
+}
+
+// AdjustOutputTime moves the time into the output timezone, if one is provided, then truncates or rounds
+// the time in that timezone's calendar
+func (tfd *TimeConverter) AdjustOutputTime(convertedTime time.Time, outputTimeZone string) (time.Time, error) {
+	if outputTimeZone != "" {
+		var err error
+		convertedTime, err = helpers.AdjustForOutputTimeZone(convertedTime, outputTimeZone)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	if helpers.CmdHelpers.TruncateBoundary != nil {
+		convertedTime = helpers.CmdHelpers.TruncateBoundary.Truncate(convertedTime)
+		helpers.CmdHelpers.InputLeapSecond = false
+	}
+
+	if helpers.CmdHelpers.RoundBoundary != nil {
+		convertedTime = helpers.CmdHelpers.RoundBoundary.Round(convertedTime)
+		helpers.CmdHelpers.InputLeapSecond = false
+	}
+
+	return convertedTime, nil
+}
+
+// parseScaledInputTime parses the input time text as a reading of the input time scale's clock, and returns the
+// UTC time.  When the reading is a leap second, CmdHelpers.InputLeapSecond is set.  On the UTC time scale, this
+// accepts leap seconds like 2016-12-31T23:59:60Z, which are read as 23:59:59.  The GPS format always uses the GPS
+// time scale, so the input time scale does not apply to it.
+func (tfd *TimeConverter) parseScaledInputTime(inputTimeText string) (time.Time, error) {
+	inputFormat := helpers.CmdHelpers.InputFormat
+	exitCode := helpers.ExitCode
+	inputTime, err := tfd.ParseInputTime(inputTimeText, inputFormat)
+	if err != nil {
+		if helpers.CmdHelpers.InputTimeScale != helpers.TimeScale_UTC || !leapSecondRegex.MatchString(inputTimeText) {
+			return time.Time{}, err
+		}
+
+		leapTime, leapErr := tfd.ParseInputTime(leapSecondRegex.ReplaceAllString(inputTimeText, "${1}:59${2}"), inputFormat)
+		if leapErr != nil || !helpers.IsLeapSecondAt(leapTime) {
+			return time.Time{}, err
+		}
+
+		helpers.ExitCode = exitCode
+		helpers.CmdHelpers.InputLeapSecond = true
+		return leapTime, nil
+	}
+
+	if inputFormat == helpers.TimeFormat_GPS {
+		return inputTime, nil
+	}
+
+	inputTime, helpers.CmdHelpers.InputLeapSecond = helpers.TimeScaleToUTC(inputTime, helpers.CmdHelpers.InputTimeScale)
+	return inputTime, nil
+}
+
+// parseISODate parses the ISO 8601 week and ordinal dates, and the lenient ISO8601 format.  Values that do not
+// include a timezone are read in the input timezone, or as UTC if no input timezone is set.
+func (tfd *TimeConverter) parseISODate(inputTimeText string, inputFormat helpers.TimeFormat) (time.Time, error) {
+	inputLocation := time.UTC
+	if helpers.CmdHelpers.InputTimeZone != "" {
+		var err error
+		inputLocation, err = helpers.CmdHelpers.InputLocation()
+		if err != nil {
+			helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+			return time.Time{}, err
+		}
+	}
+
+	switch inputFormat {
+	case helpers.TimeFormat_ISOWeekDate:
+		return helpers.ParseISOWeekDate(inputTimeText, inputLocation)
+	case helpers.TimeFormat_ISOOrdinalDate:
+		return helpers.ParseISOOrdinalDate(inputTimeText, inputLocation)
+	default:
+		return helpers.ParseISO8601(inputTimeText, inputLocation, helpers.CmdHelpers.StrictISO8601)
+	}
 }
 
 // GetPipeInput is called to retrieve data from StdIn
@@ -121,8 +654,14 @@ func (tfd *TimeConverter) GetPipeInput() ([]byte, error) {
 	return pipeBuffer.Bytes(), nil
 }
 
-// ParseInputTime is called to transform the input value text into a time.Time value based on inputFormat
+// ParseInputTime is called to transform the input value text into a time.Time value based on inputFormat.
+// When inputFormat is Auto, the detected format is stored in CmdHelpers.DetectedInputFormat.
 func (tfd *TimeConverter) ParseInputTime(inputTimeText string, inputFormat helpers.TimeFormat) (convertTime time.Time, err error) {
+	if inputFormat == helpers.TimeFormat_Auto {
+		convertTime, helpers.CmdHelpers.DetectedInputFormat, err = tfd.DetectInputTime(inputTimeText)
+		return convertTime, err
+	}
+
 	var inputUnixInt int64
 	if helpers.IsUnixTimeFormat(inputFormat) {
 		inputUnixInt, err = strconv.ParseInt(string(inputTimeText), 10, 64)
@@ -142,6 +681,45 @@ func (tfd *TimeConverter) ParseInputTime(inputTimeText string, inputFormat helpe
 		return time.UnixMicro(inputUnixInt), nil
 	case helpers.TimeFormat_Unix_Nano:
 		return time.Unix(0, inputUnixInt), nil
+	case helpers.TimeFormat_ISOWeekDate, helpers.TimeFormat_ISOOrdinalDate, helpers.TimeFormat_ISO8601:
+		return tfd.parseISODate(inputTimeText, inputFormat)
+	case helpers.TimeFormat_FileTime, helpers.TimeFormat_FileTimeHex, helpers.TimeFormat_DotNetTicks,
+		helpers.TimeFormat_Cocoa, helpers.TimeFormat_WebKit, helpers.TimeFormat_NTP, helpers.TimeFormat_NTPHex, helpers.TimeFormat_GPS,
+		helpers.TimeFormat_UnixSecsFloat, helpers.TimeFormat_UnixSecsHex, helpers.TimeFormat_UnixMilliHex,
+		helpers.TimeFormat_UnixMicroHex, helpers.TimeFormat_UnixNanoHex:
+		convertTime, err = helpers.ParseEpochTime(inputTimeText, inputFormat)
+		if err != nil {
+			helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+		}
+		return convertTime, err
+	case helpers.TimeFormat_JulianDay, helpers.TimeFormat_ModifiedJulianDay, helpers.TimeFormat_RataDie, helpers.TimeFormat_DecimalYear:
+		convertTime, err = helpers.ParseDayCountTime(inputTimeText, inputFormat)
+		if err != nil {
+			helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+		}
+		return convertTime, err
+	case helpers.TimeFormat_UUID, helpers.TimeFormat_ULID, helpers.TimeFormat_KSUID, helpers.TimeFormat_ObjectID:
+		convertTime, err = helpers.ParseIDTime(inputTimeText, inputFormat)
+		if err != nil {
+			helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+		}
+		return convertTime, err
+	case helpers.TimeFormat_Snowflake:
+		convertTime, err = helpers.ParseSnowflakeTime(inputTimeText, helpers.CmdHelpers.SnowflakeLayout)
+		if err != nil {
+			helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+		}
+		return convertTime, err
+	case helpers.TimeFormat_Excel1900, helpers.TimeFormat_Excel1904:
+		// Serial dates do not include a timezone, so they are read in the input timezone, or as UTC
+		inputLocation, err := helpers.CmdHelpers.InputLocation()
+		if err == nil {
+			convertTime, err = helpers.ParseExcelSerial(inputTimeText, inputLocation, inputFormat == helpers.TimeFormat_Excel1904)
+		}
+		if err != nil {
+			helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+		}
+		return convertTime, err
 	case helpers.TimeFormat_CustomGO:
 		layout = helpers.CmdHelpers.InputLayout
 	case helpers.TimeFormat_Custom:
@@ -155,5 +733,16 @@ func (tfd *TimeConverter) ParseInputTime(inputTimeText string, inputFormat helpe
 		layout = helpers.TimeFormatToLayout[inputFormat]
 	}
 
+	if helpers.CmdHelpers.InputTimeZone != "" {
+		// Values that include a timezone keep it, the input location only applies to values without one
+		inputLocation, err := helpers.CmdHelpers.InputLocation()
+		if err != nil {
+			helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+			return time.Time{}, err
+		}
+
+		return time.ParseInLocation(layout, inputTimeText, inputLocation)
+	}
+
 	return time.Parse(layout, inputTimeText)
 }
@@ -9,10 +9,13 @@ import (
 )
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/hobysmith/timeconverter/helpers"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Todo: Add separate and more extensive tests for the formats Custom and CustomGo?
@@ -323,7 +326,7 @@ func TestTimeConverter_Convert_Iterate_InputFormats(t *testing.T) {
 		// The following are specific cases or edge cases that
 		// are not covered by the defs above
 		{
-			// when inputformat is not defined, it should default to USDateTimeZ
+			// when inputformat is not defined, it should default to Auto
 			name:             "InputFormatNotDefined",
 			inputFormatName:  "",
 			outputFormatName: "USDateTimeZ",
@@ -331,6 +334,46 @@ func TestTimeConverter_Convert_Iterate_InputFormats(t *testing.T) {
 			testInputValue:   "2011-05-07 14:15:16 -0500",
 			wantOutputValue:  "2011-05-07 14:15:16 -0500",
 		},
+		{
+			// the default output is USDateTimeZ, which also matches EUDateTimeZ when the day is 12 or less,
+			// so detection chooses USDateTimeZ and the default output can be read back in
+			name:             "AutoDefaultOutputRoundTrip",
+			inputFormatName:  "Auto",
+			outputFormatName: "RFC3339",
+			outputTimezone:   "-0500",
+			testInputValue:   "2023-09-02 10:21:24 -0500",
+			wantOutputValue:  "2023-09-02T10:21:24-05:00",
+		},
+		{
+			name:             "AutoRFC3339",
+			inputFormatName:  "Auto",
+			outputFormatName: "UnixSecs",
+			testInputValue:   "2011-05-07T14:15:16-05:00",
+			wantOutputValue:  "1304795716",
+		},
+		{
+			name:             "AutoUnixMilli",
+			inputFormatName:  "Auto",
+			outputFormatName: "RFC3339",
+			outputTimezone:   "-0500",
+			testInputValue:   "1304795716000",
+			wantOutputValue:  "2011-05-07T14:15:16-05:00",
+		},
+		{
+			// 05/07/2011 is valid for both USDate and EUDate, so it can't be detected
+			name:             "AutoAmbiguous",
+			inputFormatName:  "Auto",
+			outputFormatName: "DateOnly",
+			testInputValue:   "05/07/2011",
+			wantErrString:    "is ambiguous",
+		},
+		{
+			name:             "AutoUnknown",
+			inputFormatName:  "Auto",
+			outputFormatName: "DateOnly",
+			testInputValue:   "not a time value",
+			wantErrString:    "Unable to detect a format",
+		},
 		{
 			// when input value is not provided, an error should be returned
 			name:             "InputValueNotProvided",
@@ -704,3 +747,1954 @@ func TestTimeConverter_Convert_Iterate_OutputFormats(t *testing.T) {
 		)
 	}
 }
+
+func TestTimeConverter_DetectInputTime(t *testing.T) {
+	tests := []struct {
+		inputValue string
+		wantFormat helpers.TimeFormat
+	}{
+		{"1304795716", helpers.TimeFormat_Unix_Secs},
+		{"1304795716123", helpers.TimeFormat_Unix_Milli},
+		{"1304795716123456", helpers.TimeFormat_Unix_Micro},
+		{"1304795716123456789", helpers.TimeFormat_Unix_Nano},
+		{"2011-05-07T14:15:16.123456789-05:00", helpers.TimeFormat_RFC3339},
+		{"Sat, 07 May 2011 14:15:16 -0500", helpers.TimeFormat_RFC1123Z},
+		{"2011-05-17 14:15:16.123 -0500", helpers.TimeFormat_USDateTimeZ},
+		{"2011-05-07 14:15:16 -0500", helpers.TimeFormat_USDateTimeZ},
+		{"17/05/2011", helpers.TimeFormat_EUDate},
+		{"2:15PM", helpers.TimeFormat_Kitchen},
+	}
+
+	for _, test := range tests {
+		t.Run(test.inputValue, func(t *testing.T) {
+			_, detectedFormat, err := New().DetectInputTime(test.inputValue)
+			assert.Nil(t, err)
+			assert.Equal(t, helpers.TimeFormatToName[test.wantFormat], helpers.TimeFormatToName[detectedFormat])
+		})
+	}
+}
+
+func TestTimeConverter_ConvertLines(t *testing.T) {
+	tests := []struct {
+		name               string
+		batchErrorModeName string
+		input              string
+		wantOutput         string
+		wantErrString      string
+	}{
+		{
+			name:               "AllLinesValid",
+			batchErrorModeName: "abort",
+			input:              "1304795716\n1304795717\r\n\n1304795718\n",
+			wantOutput:         "2011-05-07T19:15:16Z\n2011-05-07T19:15:17Z\n\n2011-05-07T19:15:18Z\n",
+		},
+		{
+			name:               "Abort",
+			batchErrorModeName: "abort",
+			input:              "1304795716\nbad value\n1304795718\n",
+			wantOutput:         "2011-05-07T19:15:16Z\n",
+			wantErrString:      "Line 2:",
+		},
+		{
+			name:               "PassThrough",
+			batchErrorModeName: "passthrough",
+			input:              "1304795716\n  bad value\n1304795718",
+			wantOutput:         "2011-05-07T19:15:16Z\n  bad value\n2011-05-07T19:15:18Z\n",
+		},
+		{
+			name:               "Marker",
+			batchErrorModeName: "Marker",
+			input:              "bad value\n1304795718\n",
+			wantOutput:         helpers.BatchErrorMarker + "\n2011-05-07T19:15:18Z\n",
+		},
+		{
+			name:               "UnknownMode",
+			batchErrorModeName: "ignore",
+			input:              "1304795716\n",
+			wantErrString:      "Unknown batch-errors mode: ignore",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.BatchErrorModeName = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = "UnixSecs"
+			helpers.CmdHelpers.OutputFormatName = "RFC3339"
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+			helpers.CmdHelpers.BatchErrorModeName = test.batchErrorModeName
+
+			tc := New()
+			assert.Nil(t, tc.ResolveFormats())
+
+			output := new(bytes.Buffer)
+			err := tc.ConvertLines(strings.NewReader(test.input), output)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.wantOutput, output.String())
+		})
+	}
+}
+
+func TestTimeConverter_RewriteText(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputFormatName string
+		inputLayout     string
+		pattern         string
+		input           string
+		wantOutput      string
+		wantErrString   string
+	}{
+		{
+			name:            "UnixMilli",
+			inputFormatName: "UnixMilli",
+			input:           "level=info ts=1304795716000 msg=\"started\" id=42\nno times here\n",
+			wantOutput:      "level=info ts=2011-05-07T19:15:16Z msg=\"started\" id=42\nno times here\n",
+		},
+		{
+			name:            "USDateTimeZ",
+			inputFormatName: "USDateTimeZ",
+			input:           "[2011-05-07 14:15:16.123 -0500] request done, [2011-05-07 14:15:17 -0500] sent",
+			wantOutput:      "[2011-05-07T19:15:16Z] request done, [2011-05-07T19:15:17Z] sent",
+		},
+		{
+			name:            "CustomLayout",
+			inputFormatName: "Custom",
+			inputLayout:     "dd/mmm/yyyy:hhh:nn:ss thhmm",
+			input:           `127.0.0.1 - - [07/May/2011:14:15:16 -0500] "GET / HTTP/1.1" 200`,
+			wantOutput:      `127.0.0.1 - - [2011-05-07T19:15:16Z] "GET / HTTP/1.1" 200`,
+		},
+		{
+			name:            "CaptureGroup",
+			inputFormatName: "UnixSecs",
+			pattern:         `started=(\d+)`,
+			input:           "started=1304795716 finished=1304795717\n",
+			wantOutput:      "started=2011-05-07T19:15:16Z finished=1304795717\n",
+		},
+		{
+			name:            "UnconvertibleMatchIsUnchanged",
+			inputFormatName: "USDate",
+			input:           "due 13/45/2011 or 05/07/2011\n",
+			wantOutput:      "due 13/45/2011 or 2011-05-07T00:00:00Z\n",
+		},
+		{
+			name:            "AutoRequiresPattern",
+			inputFormatName: "Auto",
+			input:           "1304795716\n",
+			wantErrString:   "Rewrite requires",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.RewritePattern = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = test.inputFormatName
+			helpers.CmdHelpers.InputLayout = test.inputLayout
+			helpers.CmdHelpers.OutputFormatName = "RFC3339"
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+			helpers.CmdHelpers.RewritePattern = test.pattern
+
+			tc := New()
+			assert.Nil(t, tc.ResolveFormats())
+
+			output := new(bytes.Buffer)
+			err := tc.RewriteText(strings.NewReader(test.input), output)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantOutput, output.String())
+		})
+	}
+}
+
+func TestTimeConverter_ConvertCSV(t *testing.T) {
+	tests := []struct {
+		name               string
+		columns            string
+		delimiter          string
+		hasHeader          bool
+		batchErrorModeName string
+		input              string
+		wantOutput         string
+		wantErrString      string
+	}{
+		{
+			name:       "ColumnsByName",
+			columns:    "created,updated",
+			hasHeader:  true,
+			input:      "id,created,\"updated\"\r\n1,1304795716000,\"1304795717000\"\r\n",
+			wantOutput: "id,created,\"updated\"\r\n1,2011-05-07T19:15:16Z,\"2011-05-07T19:15:17Z\"\r\n",
+		},
+		{
+			name:       "ColumnsByNumberWithoutHeader",
+			columns:    "2",
+			input:      "1,1304795716000,\"note, with \"\"quotes\"\"\nand a newline\"\n2,,empty\n",
+			wantOutput: "1,2011-05-07T19:15:16Z,\"note, with \"\"quotes\"\"\nand a newline\"\n2,,empty\n",
+		},
+		{
+			name:       "TabDelimiter",
+			columns:    "ts",
+			delimiter:  "tab",
+			hasHeader:  true,
+			input:      "ts\tname\n1304795716000\tfirst",
+			wantOutput: "ts\tname\n2011-05-07T19:15:16Z\tfirst",
+		},
+		{
+			name:               "Marker",
+			columns:            "1",
+			batchErrorModeName: "marker",
+			input:              "bad\n1304795716000\n",
+			wantOutput:         helpers.BatchErrorMarker + "\n2011-05-07T19:15:16Z\n",
+		},
+		{
+			name:          "Abort",
+			columns:       "1",
+			input:         "1304795716000\nbad\n",
+			wantOutput:    "2011-05-07T19:15:16Z\n",
+			wantErrString: "Record 2, column 1",
+		},
+		{
+			name:          "UnknownColumn",
+			columns:       "missing",
+			hasHeader:     true,
+			input:         "ts\n1304795716000\n",
+			wantErrString: "Unknown column \"missing\"",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.CSVColumns = ""
+		helpers.CmdHelpers.CSVDelimiter = ""
+		helpers.CmdHelpers.CSVHasHeader = false
+		helpers.CmdHelpers.BatchErrorModeName = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = "UnixMilli"
+			helpers.CmdHelpers.OutputFormatName = "RFC3339"
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+			helpers.CmdHelpers.CSVColumns = test.columns
+			helpers.CmdHelpers.CSVDelimiter = test.delimiter
+			helpers.CmdHelpers.CSVHasHeader = test.hasHeader
+			helpers.CmdHelpers.BatchErrorModeName = test.batchErrorModeName
+			if test.batchErrorModeName == "" {
+				helpers.CmdHelpers.BatchErrorModeName = "abort"
+			}
+
+			tc := New()
+			assert.Nil(t, tc.ResolveFormats())
+
+			output := new(bytes.Buffer)
+			err := tc.ConvertCSV(strings.NewReader(test.input), output)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.wantOutput, output.String())
+		})
+	}
+}
+
+func TestTimeConverter_ConvertJSON(t *testing.T) {
+	tests := []struct {
+		name             string
+		paths            []string
+		outputFormatName string
+		input            string
+		wantOutput       string
+		wantErrString    string
+	}{
+		{
+			name:             "NestedPathsKeepKeyOrder",
+			paths:            []string{".created_at", ".events[].ts"},
+			outputFormatName: "RFC3339",
+			input:            `{"z":1,"created_at":1304795716000,"events":[{"ts":"1304795717000","n":1.50},{"ts":null}],"a":"<&>"}`,
+			wantOutput:       `{"z":1,"created_at":"2011-05-07T19:15:16Z","events":[{"ts":"2011-05-07T19:15:17Z","n":1.50},{"ts":null}],"a":"<&>"}` + "\n",
+		},
+		{
+			name:             "JSONLinesToUnixNumbers",
+			paths:            []string{"ts"},
+			outputFormatName: "UnixSecs",
+			input:            "{\"ts\":\"1304795716000\"}\n{\"other\":1}\n{\"ts\":1304795717000}\n",
+			wantOutput:       "{\"ts\":1304795716}\n{\"other\":1}\n{\"ts\":1304795717}\n",
+		},
+		{
+			name:             "ArrayIndexAndQuotedKey",
+			paths:            []string{`.items[1]["created.at"]`},
+			outputFormatName: "DateOnly",
+			input:            `{"items":[{"created.at":1304795716000},{"created.at":1304795716000}]}`,
+			wantOutput:       `{"items":[{"created.at":1304795716000},{"created.at":"2011-05-07"}]}` + "\n",
+		},
+		{
+			name:             "InvalidPath",
+			paths:            []string{".items[x]"},
+			outputFormatName: "RFC3339",
+			input:            `{}`,
+			wantErrString:    "bad array index",
+		},
+		{
+			name:             "UnconvertibleValue",
+			paths:            []string{".ts"},
+			outputFormatName: "RFC3339",
+			input:            `{"ts":"yesterday-ish"}`,
+			wantErrString:    "JSON value 1, path .ts",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.JSONPaths = nil
+		helpers.CmdHelpers.BatchErrorModeName = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = "UnixMilli"
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+			helpers.CmdHelpers.JSONPaths = test.paths
+			helpers.CmdHelpers.BatchErrorModeName = "abort"
+
+			tc := New()
+			assert.Nil(t, tc.ResolveFormats())
+
+			output := new(bytes.Buffer)
+			err := tc.ConvertJSON(strings.NewReader(test.input), output)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantOutput, output.String())
+		})
+	}
+}
+
+func TestTimeConverter_Convert_InputTimeZone(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputFormatName string
+		inputTimezone   string
+		testInputValue  string
+		wantOutputValue string
+		wantErrString   string
+	}{
+		{
+			name:            "NoInputTimezoneIsUTC",
+			inputFormatName: "USDateTime",
+			testInputValue:  "2011-05-07 14:15:16",
+			wantOutputValue: "2011-05-07T14:15:16Z",
+		},
+		{
+			name:            "IANAInputTimezone",
+			inputFormatName: "USDateTime",
+			inputTimezone:   "America/Chicago",
+			testInputValue:  "2011-05-07 14:15:16",
+			wantOutputValue: "2011-05-07T19:15:16Z",
+		},
+		{
+			name:            "OffsetInputTimezone",
+			inputFormatName: "ANSIC",
+			inputTimezone:   "+0230",
+			testInputValue:  "Sat May  7 14:15:16 2011",
+			wantOutputValue: "2011-05-07T11:45:16Z",
+		},
+		{
+			// offsets can have any minutes up to 59, like Nepal's +0545
+			name:            "OffsetInputTimezoneMinutesOver23",
+			inputFormatName: "USDateTime",
+			inputTimezone:   "+0545",
+			testInputValue:  "2011-05-07 14:15:16",
+			wantOutputValue: "2011-05-07T08:30:16Z",
+		},
+		{
+			name:            "OffsetInputTimezoneMinutesOutOfRange",
+			inputFormatName: "USDateTime",
+			inputTimezone:   "+0560",
+			testInputValue:  "2011-05-07 14:15:16",
+			wantErrString:   "Minutes is out of range",
+		},
+		{
+			name:            "AutoDetectUsesInputTimezone",
+			inputFormatName: "Auto",
+			inputTimezone:   "America/Chicago",
+			testInputValue:  "2011-05-17",
+			wantOutputValue: "2011-05-17T05:00:00Z",
+		},
+		{
+			// values that include a timezone are not affected by the input timezone
+			name:            "ValueWithTimezoneIgnoresInputTimezone",
+			inputFormatName: "USDateTimeZ",
+			inputTimezone:   "America/Chicago",
+			testInputValue:  "2011-05-07 14:15:16 +0000",
+			wantOutputValue: "2011-05-07T14:15:16Z",
+		},
+		{
+			name:            "UnknownInputTimezone",
+			inputFormatName: "USDateTime",
+			inputTimezone:   "Nowhere/Special",
+			testInputValue:  "2011-05-07 14:15:16",
+			wantErrString:   "Unable to load indicated input timezone",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.InputTimeZone = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = test.inputFormatName
+			helpers.CmdHelpers.InputTimeZone = test.inputTimezone
+			helpers.CmdHelpers.Value = test.testInputValue
+			helpers.CmdHelpers.OutputFormatName = "RFC3339"
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantOutputValue, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_Convert_Expressions(t *testing.T) {
+	tests := []struct {
+		name            string
+		inputFormatName string
+		testInputValue  string
+		wantOutputValue string
+		wantErrString   string
+	}{
+		{
+			name:            "TimestampPlusDuration",
+			inputFormatName: "USDateTimeZ",
+			testInputValue:  "2011-05-07 14:15:16 +0000 + 1h30m",
+			wantOutputValue: "2011-05-07T15:45:16Z",
+		},
+		{
+			name:            "TimestampWithNegativeOffsetMinusDuration",
+			inputFormatName: "USDateTimeZ",
+			testInputValue:  "2011-05-07 14:15:16 -0400 -90m",
+			wantOutputValue: "2011-05-07T16:45:16Z",
+		},
+		{
+			name:            "AutoTimestampPlusDaysAndHours",
+			inputFormatName: "Auto",
+			testInputValue:  "2011-05-07T14:15:16Z+2d3h",
+			wantOutputValue: "2011-05-09T17:15:16Z",
+		},
+		{
+			name:            "UnixPlusMonthLongForm",
+			inputFormatName: "UnixSecs",
+			testInputValue:  "1304777716 + 1 month",
+			wantOutputValue: "2011-06-07T14:15:16Z",
+		},
+		{
+			name:            "MultipleOffsets",
+			inputFormatName: "UnixSecs",
+			testInputValue:  "1304777716 +1w -1.5h",
+			wantOutputValue: "2011-05-14T12:45:16Z",
+		},
+		{
+			name:            "BadBaseValue",
+			inputFormatName: "UnixSecs",
+			testInputValue:  "soon + 1h",
+			wantErrString:   "Unable to parse",
+		},
+	}
+
+	defer func() {
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = test.inputFormatName
+			helpers.CmdHelpers.Value = test.testInputValue
+			helpers.CmdHelpers.OutputFormatName = "RFC3339"
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantOutputValue, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_ParseInputValue_Keywords(t *testing.T) {
+	helpers.CmdHelpers.InputFormat = helpers.TimeFormat_Auto
+	helpers.CmdHelpers.InputTimeZone = "America/Chicago"
+	defer func() {
+		helpers.CmdHelpers.InputTimeZone = ""
+	}()
+
+	loc, err := time.LoadLocation("America/Chicago")
+	assert.Nil(t, err)
+
+	now := time.Now().In(loc)
+	year, month, day := now.Date()
+
+	tests := []struct {
+		name           string
+		testInputValue string
+		wantTime       time.Time
+		// keywords based on the current time are compared within a tolerance
+		approximate bool
+	}{
+		{name: "Now", testInputValue: "NOW", wantTime: now, approximate: true},
+		{name: "NowMinus90m", testInputValue: "now-90m", wantTime: now.Add(-90 * time.Minute), approximate: true},
+		{name: "OffsetOnly", testInputValue: "-1h", wantTime: now.Add(-time.Hour), approximate: true},
+		{name: "Today", testInputValue: "today", wantTime: time.Date(year, month, day, 0, 0, 0, 0, loc)},
+		{name: "YesterdayWithTime", testInputValue: "yesterday 09:00", wantTime: time.Date(year, month, day-1, 9, 0, 0, 0, loc)},
+		{name: "TomorrowPlusHours", testInputValue: "tomorrow 17:30:15 + 2h", wantTime: time.Date(year, month, day+1, 19, 30, 15, 0, loc)},
+		{name: "StartOfMonth", testInputValue: "start-of-month", wantTime: time.Date(year, month, 1, 0, 0, 0, 0, loc)},
+		{name: "EndOfYear", testInputValue: "end-of-year", wantTime: time.Date(year, time.December, 31, 23, 59, 59, 999999999, loc)},
+		{
+			name:           "StartOfWeekIsMonday",
+			testInputValue: "start-of-week",
+			wantTime:       time.Date(year, month, day-(int(now.Weekday())+6)%7, 0, 0, 0, 0, loc),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parsedTime, err := New().ParseInputValue(test.testInputValue)
+			assert.Nil(t, err)
+
+			if test.approximate {
+				assert.WithinDuration(t, test.wantTime, parsedTime, 5*time.Second)
+			} else {
+				assert.True(t, test.wantTime.Equal(parsedTime), "want %s, got %s", test.wantTime, parsedTime)
+			}
+			assert.Equal(t, helpers.TimeFormat_Auto, helpers.CmdHelpers.DetectedInputFormat)
+		})
+	}
+}
+
+func TestTimeConverter_Diff(t *testing.T) {
+	tests := []struct {
+		name              string
+		inputFormatName   string
+		toInputFormatName string
+		fromValue         string
+		toValue           string
+		styleName         string
+		wantOutputValue   string
+		wantErrString     string
+	}{
+		{
+			name:            "GoStyle",
+			inputFormatName: "UnixSecs",
+			fromValue:       "1304777716",
+			toValue:         "1304786716",
+			styleName:       "go",
+			wantOutputValue: "2h30m0s",
+		},
+		{
+			name:              "DifferentFormatsMillis",
+			inputFormatName:   "USDateTimeZ",
+			toInputFormatName: "UnixMilli",
+			fromValue:         "2011-05-07 14:15:16 -0400",
+			toValue:           "1304792116250",
+			styleName:         "millis",
+			wantOutputValue:   "250",
+		},
+		{
+			name:            "NegativeSecs",
+			inputFormatName: "UnixSecs",
+			fromValue:       "1304777716",
+			toValue:         "1304777700",
+			styleName:       "secs",
+			wantOutputValue: "-16",
+		},
+		{
+			name:            "ISOStyle",
+			inputFormatName: "RFC3339",
+			fromValue:       "2011-05-07T14:15:16Z",
+			toValue:         "2011-05-08T16:15:16Z",
+			styleName:       "iso",
+			wantOutputValue: "P1DT2H",
+		},
+		{
+			name:            "ISOStyleZero",
+			inputFormatName: "RFC3339",
+			fromValue:       "2011-05-07T14:15:16Z",
+			toValue:         "2011-05-07T14:15:16Z",
+			styleName:       "iso",
+			wantOutputValue: "PT0S",
+		},
+		{
+			// Jan 31 plus 1 month is clamped to the end of February
+			name:            "HumanStyleMonthEnd",
+			inputFormatName: "RFC3339",
+			fromValue:       "2011-01-31T00:00:00Z",
+			toValue:         "2012-03-01T01:00:01.5Z",
+			styleName:       "human",
+			wantOutputValue: "1 year, 1 month, 1 day, 1 hour, 1 second",
+		},
+		{
+			name:            "NegativeHumanStyle",
+			inputFormatName: "RFC3339",
+			fromValue:       "2011-05-09T14:15:16Z",
+			toValue:         "2011-05-07T14:15:16Z",
+			styleName:       "human",
+			wantOutputValue: "-2 days",
+		},
+		{
+			name:            "UnknownStyle",
+			inputFormatName: "UnixSecs",
+			fromValue:       "1304777716",
+			toValue:         "1304777700",
+			styleName:       "weeks",
+			wantErrString:   "Unknown diff style",
+		},
+		{
+			name:              "BadSecondValue",
+			inputFormatName:   "UnixSecs",
+			toInputFormatName: "RFC3339",
+			fromValue:         "1304777716",
+			toValue:           "1304777700",
+			styleName:         "go",
+			wantErrString:     "Unable to parse \"1304777700\" using format RFC3339",
+		},
+		{
+			name:              "UnknownToInputFormat",
+			inputFormatName:   "UnixSecs",
+			toInputFormatName: "UnixWeeks",
+			fromValue:         "1304777716",
+			toValue:           "1304777700",
+			styleName:         "go",
+			wantErrString:     "Unknown to-input-format: UnixWeeks",
+		},
+		{
+			name:              "OutputOnlyToInputFormat",
+			inputFormatName:   "UnixSecs",
+			toInputFormatName: "Relative",
+			fromValue:         "1304777716",
+			toValue:           "5 minutes ago",
+			styleName:         "go",
+			wantErrString:     "Relative is only supported as an output format",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.DiffToValue = ""
+		helpers.CmdHelpers.DiffToInputFormatName = ""
+		helpers.CmdHelpers.DiffStyleName = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = test.inputFormatName
+			helpers.CmdHelpers.DiffToInputFormatName = test.toInputFormatName
+			helpers.CmdHelpers.Value = test.fromValue
+			helpers.CmdHelpers.DiffToValue = test.toValue
+			helpers.CmdHelpers.DiffStyleName = test.styleName
+
+			err := New().Diff(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantOutputValue, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_Convert_AddSubtract(t *testing.T) {
+	tests := []struct {
+		name             string
+		testInputValue   string
+		addDuration      string
+		subtractDuration string
+		monthEndModeName string
+		wantOutputValue  string
+		wantErrString    string
+	}{
+		{
+			name:            "AddClock",
+			testInputValue:  "2011-05-07T14:15:16Z",
+			addDuration:     "1h30m",
+			wantOutputValue: "2011-05-07T15:45:16Z",
+		},
+		{
+			name:             "SubtractDays",
+			testInputValue:   "2011-05-07T14:15:16Z",
+			subtractDuration: "90d",
+			wantOutputValue:  "2011-02-06T14:15:16Z",
+		},
+		{
+			name:            "AddMonthClampDefault",
+			testInputValue:  "2011-01-31T14:15:16Z",
+			addDuration:     "1mo",
+			wantOutputValue: "2011-02-28T14:15:16Z",
+		},
+		{
+			name:             "AddMonthOverflow",
+			testInputValue:   "2011-01-31T14:15:16Z",
+			addDuration:      "1mo",
+			monthEndModeName: "overflow",
+			wantOutputValue:  "2011-03-03T14:15:16Z",
+		},
+		{
+			name:             "SubtractYearFromLeapDayClamp",
+			testInputValue:   "2012-02-29T14:15:16Z",
+			subtractDuration: "1y",
+			monthEndModeName: "clamp",
+			wantOutputValue:  "2011-02-28T14:15:16Z",
+		},
+		{
+			name:             "AddAndSubtract",
+			testInputValue:   "2011-05-07T14:15:16Z",
+			addDuration:      "1w",
+			subtractDuration: "16s",
+			wantOutputValue:  "2011-05-14T14:15:00Z",
+		},
+		{
+			name:           "BadDuration",
+			testInputValue: "2011-05-07T14:15:16Z",
+			addDuration:    "1fortnight",
+			wantErrString:  "Invalid add value",
+		},
+		{
+			name:             "BadMonthEndMode",
+			testInputValue:   "2011-05-07T14:15:16Z",
+			addDuration:      "1mo",
+			monthEndModeName: "wrap",
+			wantErrString:    "Unknown month-end mode",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.AddDuration = ""
+		helpers.CmdHelpers.SubtractDuration = ""
+		helpers.CmdHelpers.MonthEndModeName = ""
+		helpers.CmdHelpers.TimeOffsets = nil
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = "RFC3339"
+			helpers.CmdHelpers.Value = test.testInputValue
+			helpers.CmdHelpers.AddDuration = test.addDuration
+			helpers.CmdHelpers.SubtractDuration = test.subtractDuration
+			helpers.CmdHelpers.MonthEndModeName = test.monthEndModeName
+			helpers.CmdHelpers.OutputFormatName = "RFC3339"
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantOutputValue, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_Convert_TruncateRound(t *testing.T) {
+	tests := []struct {
+		name            string
+		testInputValue  string
+		outputTimezone  string
+		truncateUnit    string
+		roundUnit       string
+		weekStartName   string
+		wantOutputValue string
+		wantErrString   string
+	}{
+		{
+			name:            "TruncateMinute",
+			testInputValue:  "2011-05-07T14:15:16Z",
+			truncateUnit:    "minute",
+			wantOutputValue: "2011-05-07T14:15:00Z",
+		},
+		{
+			name:            "TruncateFiveMinuteBucket",
+			testInputValue:  "2011-05-07T14:19:59.999Z",
+			truncateUnit:    "5m",
+			wantOutputValue: "2011-05-07T14:15:00Z",
+		},
+		{
+			name:            "RoundHalfUp",
+			testInputValue:  "2011-05-07T14:30:00Z",
+			roundUnit:       "hour",
+			wantOutputValue: "2011-05-07T15:00:00Z",
+		},
+		{
+			// the day starts at midnight in the output timezone, not in UTC
+			name:            "TruncateDayInOutputTimezone",
+			testInputValue:  "2011-05-07T03:15:16Z",
+			outputTimezone:  "America/Chicago",
+			truncateUnit:    "day",
+			wantOutputValue: "2011-05-06T00:00:00-05:00",
+		},
+		{
+			// hours follow the wall clock in zones with a half hour offset
+			name:            "TruncateHourHalfHourOffset",
+			testInputValue:  "2011-05-07T14:15:16Z",
+			outputTimezone:  "Asia/Kolkata",
+			truncateUnit:    "hour",
+			wantOutputValue: "2011-05-07T19:00:00+05:30",
+		},
+		{
+			name:            "TruncateWeekMonday",
+			testInputValue:  "2011-05-07T14:15:16Z",
+			truncateUnit:    "week",
+			wantOutputValue: "2011-05-02T00:00:00Z",
+		},
+		{
+			name:            "TruncateWeekSunday",
+			testInputValue:  "2011-05-07T14:15:16Z",
+			truncateUnit:    "week",
+			weekStartName:   "sunday",
+			wantOutputValue: "2011-05-01T00:00:00Z",
+		},
+		{
+			name:            "TruncateQuarter",
+			testInputValue:  "2011-05-07T14:15:16Z",
+			truncateUnit:    "quarter",
+			wantOutputValue: "2011-04-01T00:00:00Z",
+		},
+		{
+			name:            "RoundMonth",
+			testInputValue:  "2011-05-17T14:15:16Z",
+			roundUnit:       "month",
+			wantOutputValue: "2011-06-01T00:00:00Z",
+		},
+		{
+			name:            "TruncateYear",
+			testInputValue:  "2011-05-07T14:15:16Z",
+			truncateUnit:    "years",
+			wantOutputValue: "2011-01-01T00:00:00Z",
+		},
+		{
+			// 02:00 does not exist on this day, so the next hour boundary is 03:00 CDT
+			name:            "RoundHourIntoDSTGap",
+			testInputValue:  "2011-03-13T07:38:45Z",
+			outputTimezone:  "America/Chicago",
+			roundUnit:       "hour",
+			wantOutputValue: "2011-03-13T03:00:00-05:00",
+		},
+		{
+			name:           "BothTruncateAndRound",
+			testInputValue: "2011-05-07T14:15:16Z",
+			truncateUnit:   "day",
+			roundUnit:      "hour",
+			wantErrString:  "Use either truncate or round",
+		},
+		{
+			name:           "DurationLongerThanDay",
+			testInputValue: "2011-05-07T14:15:16Z",
+			truncateUnit:   "2d",
+			wantErrString:  "no longer than a day",
+		},
+		{
+			name:           "UnknownWeekStart",
+			testInputValue: "2011-05-07T14:15:16Z",
+			truncateUnit:   "week",
+			weekStartName:  "someday",
+			wantErrString:  "Unknown week-start day",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.TruncateUnitName = ""
+		helpers.CmdHelpers.RoundUnitName = ""
+		helpers.CmdHelpers.TruncateBoundary = nil
+		helpers.CmdHelpers.RoundBoundary = nil
+		helpers.CmdHelpers.WeekStartName = ""
+		helpers.CmdHelpers.WeekStart = time.Monday
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = "RFC3339"
+			helpers.CmdHelpers.Value = test.testInputValue
+			helpers.CmdHelpers.TruncateUnitName = test.truncateUnit
+			helpers.CmdHelpers.RoundUnitName = test.roundUnit
+			helpers.CmdHelpers.WeekStartName = test.weekStartName
+			helpers.CmdHelpers.OutputFormatName = "RFC3339"
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+			if test.outputTimezone != "" {
+				helpers.CmdHelpers.OutputTimeZone = test.outputTimezone
+			}
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantOutputValue, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_Convert_MultipleOutputFormats(t *testing.T) {
+	tests := []struct {
+		name             string
+		outputFormatName string
+		outputLayout     string
+		wantResults      []string
+		wantErrString    string
+	}{
+		{
+			name:             "CommaSeparated",
+			outputFormatName: "UnixMilli,RFC3339,USDateTimeZ",
+			wantResults:      []string{"1304792116000", "2011-05-07T18:15:16Z", "2011-05-07 18:15:16 +0000"},
+		},
+		{
+			name:             "LayoutPerFormat",
+			outputFormatName: "CustomGO=2006-01-02,Custom=yyyy,UnixSecs",
+			wantResults:      []string{"2011-05-07", "2011", "1304792116"},
+		},
+		{
+			// commas in a layout do not start a new format unless they are followed by a format name
+			name:             "LayoutWithComma",
+			outputFormatName: "CustomGO=Jan 2, 2006,RFC3339",
+			wantResults:      []string{"May 7, 2011", "2011-05-07T18:15:16Z"},
+		},
+		{
+			name:             "DefaultOutputLayout",
+			outputFormatName: "UnixSecs,CustomGO",
+			outputLayout:     "15:04",
+			wantResults:      []string{"1304792116", "18:15"},
+		},
+		{
+			name:             "UnknownFormat",
+			outputFormatName: "UnixSecs,NotAFormat",
+			wantErrString:    "Unknown output-format: NotAFormat",
+		},
+		{
+			name:             "LayoutOnNonCustomFormat",
+			outputFormatName: "RFC3339=2006",
+			wantErrString:    "does not use a layout",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.OutputLayout = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = "USDateTimeZ"
+			helpers.CmdHelpers.Value = "2011-05-07 14:15:16 -0400"
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.OutputLayout = test.outputLayout
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResults, helpers.CmdHelpers.ConvertedResults)
+			assert.Equal(t, test.wantResults[0], helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_ConvertLines_MultipleOutputFormats(t *testing.T) {
+	helpers.CmdHelpers.InputFormatName = "UnixSecs"
+	helpers.CmdHelpers.OutputFormatName = "UnixMilli,RFC3339"
+	helpers.CmdHelpers.BatchErrorModeName = "abort"
+
+	tc := New()
+	assert.Nil(t, tc.ResolveFormats())
+
+	err := tc.ConvertLines(strings.NewReader("1304777716\n"), new(bytes.Buffer))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Multiple output formats are only supported when converting a single value")
+
+	helpers.CmdHelpers.OutputFormatName = "UnixMilli"
+	helpers.CmdHelpers.OutputTimeZone = "UTC,America/Chicago"
+	defer func() {
+		helpers.CmdHelpers.OutputTimeZone = ""
+		helpers.CmdHelpers.OutputTimeZones = nil
+	}()
+	assert.Nil(t, tc.ResolveFormats())
+
+	err = tc.ConvertLines(strings.NewReader("1304777716\n"), new(bytes.Buffer))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Multiple output timezones are only supported when converting a single value")
+}
+
+func TestTimeConverter_Convert_MultipleOutputTimeZones(t *testing.T) {
+	tests := []struct {
+		name             string
+		outputTimezone   string
+		outputFormatName string
+		truncateUnit     string
+		wantResults      []string
+		wantErrString    string
+	}{
+		{
+			name:             "IANAAndOffsetZones",
+			outputTimezone:   "America/Chicago,Europe/London, +0930",
+			outputFormatName: "RFC3339",
+			wantResults:      []string{"2011-05-07T13:15:16-05:00", "2011-05-07T19:15:16+01:00", "2011-05-08T03:45:16+09:30"},
+		},
+		{
+			// results are ordered by zone, then by format
+			name:             "ZonesAndFormats",
+			outputTimezone:   "UTC,Asia/Kolkata",
+			outputFormatName: "RFC3339,UnixSecs",
+			wantResults:      []string{"2011-05-07T18:15:16Z", "1304792116", "2011-05-07T23:45:16+05:30", "1304792116"},
+		},
+		{
+			// each zone is truncated in its own calendar
+			name:             "TruncatePerZone",
+			outputTimezone:   "UTC,America/Chicago",
+			outputFormatName: "RFC3339",
+			truncateUnit:     "day",
+			wantResults:      []string{"2011-05-07T00:00:00Z", "2011-05-07T00:00:00-05:00"},
+		},
+		{
+			name:             "UnknownZone",
+			outputTimezone:   "UTC,Nowhere/Special",
+			outputFormatName: "RFC3339",
+			wantErrString:    "Unable to load indicated output timezone",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.OutputTimeZone = ""
+		helpers.CmdHelpers.OutputTimeZones = nil
+		helpers.CmdHelpers.TruncateUnitName = ""
+		helpers.CmdHelpers.TruncateBoundary = nil
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = "USDateTimeZ"
+			helpers.CmdHelpers.Value = "2011-05-07 14:15:16 -0400"
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.OutputTimeZone = test.outputTimezone
+			helpers.CmdHelpers.TruncateUnitName = test.truncateUnit
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResults, helpers.CmdHelpers.ConvertedResults)
+		})
+	}
+}
+
+func TestTimeConverter_ConversionDocument(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	assert.Nil(t, err)
+
+	defer func() {
+		helpers.CmdHelpers.OutputTimeZone = ""
+		helpers.CmdHelpers.OutputTimeZones = nil
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	helpers.CmdHelpers.InputFormatName = "Auto"
+	helpers.CmdHelpers.Value = "2011-05-07T14:15:16-04:00"
+	helpers.CmdHelpers.OutputFormatName = "RFC3339,UnixSecs"
+	helpers.CmdHelpers.OutputTimeZone = "+0930,America/Chicago"
+
+	err = New().Convert(true)
+	assert.Nil(t, err)
+
+	inputTime := time.Date(2011, 5, 7, 18, 15, 16, 0, time.UTC)
+	zoneTimes := []time.Time{inputTime.In(time.FixedZone("", 34200)), inputTime.In(chicago)}
+	doc := BuildConversionDocument(helpers.CmdHelpers.Value, zoneTimes)
+
+	encodedDoc, err := EncodeConversionDocument(doc, helpers.OutputEncoding_JSON)
+	assert.Nil(t, err)
+	assert.Equal(t, `{
+  "input": "2011-05-07T14:15:16-04:00",
+  "inputFormat": "Auto",
+  "detectedInputFormat": "RFC3339",
+  "unixNano": 1304792116000000000,
+  "results": [
+    {
+      "outputFormat": "RFC3339",
+      "result": "2011-05-08T03:45:16+09:30",
+      "timezone": "+09:30",
+      "offsetSeconds": 34200
+    },
+    {
+      "outputFormat": "UnixSecs",
+      "result": "1304792116",
+      "timezone": "+09:30",
+      "offsetSeconds": 34200
+    },
+    {
+      "outputFormat": "RFC3339",
+      "result": "2011-05-07T13:15:16-05:00",
+      "timezone": "America/Chicago",
+      "zoneAbbreviation": "CDT",
+      "offsetSeconds": -18000
+    },
+    {
+      "outputFormat": "UnixSecs",
+      "result": "1304792116",
+      "timezone": "America/Chicago",
+      "zoneAbbreviation": "CDT",
+      "offsetSeconds": -18000
+    }
+  ]
+}
+`, encodedDoc)
+
+	helpers.CmdHelpers.InputFormatName = "UnixSecs"
+	helpers.CmdHelpers.Value = "not-a-time"
+	helpers.CmdHelpers.OutputTimeZone = ""
+
+	err = New().Convert(true)
+	assert.NotNil(t, err)
+
+	encodedDoc, err = EncodeConversionDocument(BuildErrorDocument(helpers.CmdHelpers.Value, err), helpers.OutputEncoding_YAML)
+	assert.Nil(t, err)
+	assert.Equal(t, `input: not-a-time
+inputFormat: UnixSecs
+error:
+    exitCode: 8
+    exitCodeName: ExitCodeErrorDecodingInput
+    message: Unable to parse "not-a-time" using format UnixSecs. Input value or format is not correct.
+`, encodedDoc)
+}
+
+func TestTimeConverter_Convert_OutputTemplate(t *testing.T) {
+	tests := []struct {
+		name             string
+		outputTemplate   string
+		outputFormatName string
+		outputTimezone   string
+		wantResults      []string
+		wantErrString    string
+	}{
+		{
+			name:           "NamedFormatsAndUnix",
+			outputTemplate: "backup-{{.DateOnly}}-{{.UnixSecs}}.tar",
+			wantResults:    []string{"backup-2011-05-07-1304792116.tar"},
+		},
+		{
+			name:           "DateParts",
+			outputTemplate: `{{.Year}}-W{{printf "%02d" .ISOWeek}}-{{.ISOWeekday}} Q{{.Quarter}} day {{.YearDay}} {{.Weekday}} {{.Hour12}}{{.AMPM}}`,
+			wantResults:    []string{"2011-W18-6 Q2 day 127 Saturday 2PM"},
+		},
+		{
+			name:           "Zone",
+			outputTemplate: "{{.Zone}} {{.ZoneName}} {{.Offset}} {{.OffsetSeconds}} {{.IsDST}}",
+			outputTimezone: "America/Chicago",
+			wantResults:    []string{"CDT America/Chicago -05:00 -18000 true"},
+		},
+		{
+			name:           "FixedOffsetZone",
+			outputTemplate: "{{.ZoneName}} {{.RFC3339}}",
+			wantResults:    []string{"-04:00 2011-05-07T14:15:16-04:00"},
+		},
+		{
+			name:           "TimeMethods",
+			outputTemplate: `{{.Time.Format "Jan 2006"}}`,
+			wantResults:    []string{"May 2011"},
+		},
+		{
+			// each zone is rendered with its own template output
+			name:           "SeveralZones",
+			outputTemplate: "{{.TimeOnly}}",
+			outputTimezone: "UTC,Asia/Tokyo",
+			wantResults:    []string{"18:15:16", "03:15:16"},
+		},
+		{
+			name:           "UnknownField",
+			outputTemplate: "{{.Nope}}",
+			wantErrString:  `map has no entry for key "Nope"`,
+		},
+		{
+			name:           "InvalidTemplate",
+			outputTemplate: "{{.Year",
+			wantErrString:  "Invalid output template",
+		},
+		{
+			name:             "SeveralFormats",
+			outputTemplate:   "{{.Year}}",
+			outputFormatName: "RFC3339,UnixSecs",
+			wantErrString:    "Use either multiple output formats or an output template, not both",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.OutputTemplateText = ""
+		helpers.CmdHelpers.OutputTimeZone = ""
+		helpers.CmdHelpers.OutputTimeZones = nil
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = "USDateTimeZ"
+			helpers.CmdHelpers.Value = "2011-05-07 14:15:16 -0400"
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.OutputTimeZone = test.outputTimezone
+			helpers.CmdHelpers.OutputTemplateText = test.outputTemplate
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResults, helpers.CmdHelpers.ConvertedResults)
+		})
+	}
+}
+
+func TestTimeConverter_Explain(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	assert.Nil(t, err)
+
+	defer func() {
+		helpers.CmdHelpers.InputTimeZone = ""
+		helpers.CmdHelpers.OutputTimeZone = ""
+		helpers.CmdHelpers.OutputTimeZones = nil
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	helpers.CmdHelpers.InputFormatName = "USDateTime"
+	helpers.CmdHelpers.Value = "2024-02-29 10:00:00"
+	helpers.CmdHelpers.OutputFormatName = ""
+	helpers.CmdHelpers.InputTimeZone = "America/Chicago"
+	helpers.CmdHelpers.OutputTimeZone = "America/Chicago"
+
+	err = New().Explain(true)
+	assert.Nil(t, err)
+
+	report := helpers.CmdHelpers.ConvertedResult
+	for _, wantLine := range []string{
+		"Input: 2024-02-29 10:00:00",
+		"UTC             : 2024-02-29T16:00:00Z",
+		"America/Chicago : 2024-02-29T10:00:00-06:00 (CST)",
+		"Weekday     : Thursday",
+		"ISO Week    : 2024-W09-4",
+		"Day of Year : 60",
+		"Quarter     : Q1",
+		"Leap Year   : yes",
+		"Zone                : CST -06:00",
+		"DST In Effect       : no",
+		"Previous Transition : 2023-11-05T01:00:00-06:00 (CDT -05:00 to CST -06:00)",
+		"Next Transition     : 2024-03-10T03:00:00-05:00 (CST -06:00 to CDT -05:00)",
+		"RFC3339           : 2024-02-29T10:00:00-06:00",
+		"UnixSecs          : 1709222400",
+	} {
+		assert.Contains(t, report, wantLine)
+	}
+
+	// zones without transitions report none
+	report = ExplainTime("0", time.Unix(0, 0).UTC(), time.Unix(0, 0))
+	assert.Contains(t, report, "Previous Transition : none")
+	assert.Contains(t, report, "Relative To Now : now")
+
+	targetTime := time.Date(2023, 9, 24, 10, 0, 0, 0, chicago)
+	assert.Contains(t, ExplainTime("", targetTime, targetTime.Add(76*time.Hour+5*time.Minute)), "Relative To Now : 3 days, 4 hours ago")
+	assert.Contains(t, ExplainTime("", targetTime, targetTime.AddDate(0, -2, -1)), "Relative To Now : in 2 months, 1 day")
+
+	helpers.CmdHelpers.OutputTimeZone = "UTC,America/Chicago"
+	err = New().Explain(true)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Explain supports a single output timezone")
+}
+
+func TestTimeConverter_Convert_Relative(t *testing.T) {
+	tests := []struct {
+		name             string
+		value            string
+		outputFormatName string
+		relativeTo       string
+		wantResult       string
+		wantErrString    string
+	}{
+		{
+			name:             "HoursAgo",
+			value:            "2023-09-03T01:30:00Z",
+			outputFormatName: "Relative",
+			relativeTo:       "2023-09-03T04:59:00Z",
+			wantResult:       "3 hours ago",
+		},
+		{
+			name:             "InDays",
+			value:            "2023-09-05T05:00:00Z",
+			outputFormatName: "relative",
+			relativeTo:       "2023-09-03T04:00:00Z",
+			wantResult:       "in 2 days",
+		},
+		{
+			name:             "OneMonthAgo",
+			value:            "2023-01-31T00:00:00Z",
+			outputFormatName: "Relative",
+			relativeTo:       "2023-02-28T00:00:00Z",
+			wantResult:       "1 month ago",
+		},
+		{
+			name:             "Precise",
+			value:            "2023-09-01T00:00:00Z",
+			outputFormatName: "RelativePrecise",
+			relativeTo:       "2023-09-03T04:13:20Z",
+			wantResult:       "2d 4h 13m 20s ago",
+		},
+		{
+			name:             "PreciseFuture",
+			value:            "2024-10-04T00:00:00Z",
+			outputFormatName: "RelativePrecise",
+			relativeTo:       "2023-09-03T00:00:00Z",
+			wantResult:       "in 1y 1mo 1d",
+		},
+		{
+			name:             "Now",
+			value:            "2023-09-03T00:00:00.5Z",
+			outputFormatName: "Relative",
+			relativeTo:       "2023-09-03T00:00:00Z",
+			wantResult:       "now",
+		},
+		{
+			// the reference can be a relative expression
+			name:             "ExpressionReference",
+			value:            "now-90m",
+			outputFormatName: "Relative",
+			relativeTo:       "now",
+			wantResult:       "1 hour ago",
+		},
+		{
+			name:             "DefaultsToNow",
+			value:            "now+50h",
+			outputFormatName: "Relative",
+			wantResult:       "in 2 days",
+		},
+		{
+			name:             "InvalidReference",
+			value:            "now",
+			outputFormatName: "Relative",
+			relativeTo:       "not a time",
+			wantErrString:    "Invalid relative-to value",
+		},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.RelativeToValue = ""
+		helpers.CmdHelpers.RelativeTo = time.Time{}
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = "Auto"
+			helpers.CmdHelpers.Value = test.value
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.RelativeToValue = test.relativeTo
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResult, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+
+	helpers.CmdHelpers.InputFormatName = "Relative"
+	helpers.CmdHelpers.Value = "3 hours ago"
+	err := New().Convert(true)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "Relative is only supported as an output format")
+}
+
+func TestTimeConverter_Convert_ISODateFormats(t *testing.T) {
+	tests := []struct {
+		name             string
+		value            string
+		inputFormatName  string
+		outputFormatName string
+		inputTimezone    string
+		wantResult       string
+		wantErrString    string
+	}{
+		{"WeekDateToRFC3339", "2023-W35-6", "ISOWeekDate", "RFC3339", "", "2023-09-02T00:00:00Z", ""},
+		{"WeekDateInPriorYear", "2020-W53-7", "ISOWeekDate", "DateOnly", "", "2021-01-03", ""},
+		{"WeekDateWeek1InPriorYear", "2025-W01-1", "ISOWeekDate", "DateOnly", "", "2024-12-30", ""},
+		{"WeekDateInInputTimezone", "2023-W35-6", "ISOWeekDate", "RFC3339", "America/Chicago", "2023-09-02T00:00:00-05:00", ""},
+		{"WeekDateTooManyWeeks", "2021-W53-1", "ISOWeekDate", "DateOnly", "", "", "Unable to parse"},
+		{"WeekDateDetected", "2023-W35-6", "Auto", "DateOnly", "", "2023-09-02", ""},
+		{"RFC3339ToWeekDate", "2021-01-03T10:00:00Z", "RFC3339", "ISOWeekDate", "", "2020-W53-7", ""},
+		{"RFC3339ToWeekDateNextYear", "2024-12-30T10:00:00Z", "RFC3339", "ISOWeekDate", "", "2025-W01-1", ""},
+		{"OrdinalToDateOnly", "2023-244", "ISOOrdinalDate", "DateOnly", "", "2023-09-01", ""},
+		{"OrdinalLeapDay", "2024-366", "ISOOrdinalDate", "DateOnly", "", "2024-12-31", ""},
+		{"OrdinalTooManyDays", "2023-366", "ISOOrdinalDate", "DateOnly", "", "", "Unable to parse"},
+		{"OrdinalDetected", "2023-001", "Auto", "DateOnly", "", "2023-01-01", ""},
+		{"RFC3339ToOrdinal", "2023-02-05T10:00:00Z", "RFC3339", "ISOOrdinalDate", "", "2023-036", ""},
+		{"BasicToRFC3339", "20230902T102124Z", "ISOBasic", "RFC3339", "", "2023-09-02T10:21:24Z", ""},
+		{"BasicWithOffset", "20230902T102124-0500", "Auto", "RFC3339", "", "2023-09-02T10:21:24-05:00", ""},
+		{"RFC3339ToBasic", "2023-09-02T10:21:24-05:00", "RFC3339", "ISOBasic", "", "20230902T102124-0500", ""},
+		{"RFC3339ToBasicUTC", "2023-09-02T10:21:24Z", "RFC3339", "ISOBasic", "", "20230902T102124Z", ""},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.InputTimeZone = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = test.inputFormatName
+			helpers.CmdHelpers.Value = test.value
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.InputTimeZone = test.inputTimezone
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResult, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_Convert_ISO8601(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		strict        bool
+		inputTimezone string
+		wantResult    string
+		wantErrString string
+	}{
+		{"RFC3339", "2023-09-02T10:21:24Z", false, "", "2023-09-02T10:21:24Z", ""},
+		{"SpaceSeparator", "2023-09-02 10:21:24Z", false, "", "2023-09-02T10:21:24Z", ""},
+		{"LowercaseDesignators", "2023-09-02t10:21:24z", false, "", "2023-09-02T10:21:24Z", ""},
+		{"MissingSeconds", "2023-09-02T10:21Z", false, "", "2023-09-02T10:21:00Z", ""},
+		{"HoursOnly", "2023-09-02T10Z", false, "", "2023-09-02T10:00:00Z", ""},
+		{"CommaFraction", "2023-09-02T10:21:24,5Z", false, "", "2023-09-02T10:21:24.5Z", ""},
+		{"FractionalHour", "2023-09-02T10.5Z", false, "", "2023-09-02T10:30:00Z", ""},
+		{"HourOffset", "2023-09-02T10:21:24+05", false, "", "2023-09-02T10:21:24+05:00", ""},
+		{"BasicOffset", "2023-09-02T10:21:24-0530", false, "", "2023-09-02T10:21:24-05:30", ""},
+		{"BasicForm", "20230902T102124Z", false, "", "2023-09-02T10:21:24Z", ""},
+		{"WeekDateTime", "2023-W35-6T10:00Z", false, "", "2023-09-02T10:00:00Z", ""},
+		{"OrdinalDateTime", "2023-245T10:00Z", false, "", "2023-09-02T10:00:00Z", ""},
+		{"DateOnly", "2023-09-02", false, "", "2023-09-02T00:00:00Z", ""},
+		{"YearMonth", "2023-09", false, "", "2023-09-01T00:00:00Z", ""},
+		{"EndOfDay", "2023-09-02T24:00Z", false, "", "2023-09-03T00:00:00Z", ""},
+		{"NoOffsetUsesInputTimezone", "2023-09-02 10:21", false, "America/Chicago", "2023-09-02T10:21:00-05:00", ""},
+		{"InvalidDay", "2023-02-30", false, "", "", "Unable to parse"},
+		{"InvalidEndOfDay", "2023-09-02T24:30Z", false, "", "", "Unable to parse"},
+		{"InvalidMinute", "2023-09-02T10:60Z", false, "", "", "Unable to parse"},
+		{"InvalidOffset", "2023-09-02T10:21+5", false, "", "", "Unable to parse"},
+		{"StrictRFC3339", "2023-09-02T10:21:24.5-05:00", true, "", "2023-09-02T10:21:24.5-05:00", ""},
+		{"StrictRejectsSpace", "2023-09-02 10:21:24Z", true, "", "", "Unable to parse"},
+		{"StrictRejectsMissingSeconds", "2023-09-02T10:21Z", true, "", "", "Unable to parse"},
+		{"StrictRejectsHourOffset", "2023-09-02T10:21:24+05", true, "", "", "Unable to parse"},
+		{"StrictRejectsMissingOffset", "2023-09-02T10:21:24", true, "", "", "Unable to parse"},
+		{"StrictRejectsHour24", "2023-09-02T24:00:00Z", true, "", "", "Unable to parse"},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.InputTimeZone = ""
+		helpers.CmdHelpers.StrictISO8601 = false
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = "ISO8601"
+			helpers.CmdHelpers.Value = test.value
+			helpers.CmdHelpers.OutputFormatName = "RFC3339Nano"
+			helpers.CmdHelpers.InputTimeZone = test.inputTimezone
+			helpers.CmdHelpers.StrictISO8601 = test.strict
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResult, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_Convert_EpochFormats(t *testing.T) {
+	tests := []struct {
+		name             string
+		value            string
+		inputFormatName  string
+		outputFormatName string
+		inputTimezone    string
+		wantResult       string
+		wantErrString    string
+	}{
+		{"FileTimeToRFC3339", "133381236840000000", "FileTime", "RFC3339Nano", "", "2023-09-02T10:21:24Z", ""},
+		{"FileTimeFraction", "133381236841234567", "FileTime", "RFC3339Nano", "", "2023-09-02T10:21:24.1234567Z", ""},
+		{"FileTimeEpoch", "0", "FileTime", "RFC3339", "", "1601-01-01T00:00:00Z", ""},
+		{"FileTimeNegative", "-1", "FileTime", "RFC3339", "", "", "Unable to parse"},
+		{"RFC3339ToFileTime", "2023-09-02T10:21:24.1234567Z", "RFC3339Nano", "FileTime", "", "133381236841234567", ""},
+		{"FileTimeBefore1601", "1500-01-01T00:00:00Z", "RFC3339", "FileTime", "", "", "before 1601-01-01"},
+		{"FileTimeHexToRFC3339", "0x01D9DD87398CEA00", "FileTimeHex", "RFC3339", "", "2023-09-02T10:21:24Z", ""},
+		{"FileTimeHexNoPrefix", "01d9dd87398cea00", "FileTimeHex", "RFC3339", "", "2023-09-02T10:21:24Z", ""},
+		{"FileTimeHexInvalid", "0x01D9DD87398CEA0G", "FileTimeHex", "RFC3339", "", "", "Unable to parse"},
+		{"RFC3339ToFileTimeHex", "2023-09-02T10:21:24Z", "RFC3339", "FileTimeHex", "", "0x01D9DD87398CEA00", ""},
+		{"DotNetTicksToRFC3339", "638292468840000000", "DotNetTicks", "RFC3339", "", "2023-09-02T10:21:24Z", ""},
+		{"DotNetTicksUnixEpoch", "621355968000000000", "DotNetTicks", "UnixSecs", "", "0", ""},
+		{"RFC3339ToDotNetTicks", "2023-09-02T10:21:24Z", "RFC3339", "DotNetTicks", "", "638292468840000000", ""},
+		{"Excel1900ToRFC3339", "45171.5", "Excel1900", "RFC3339", "", "2023-09-02T12:00:00Z", ""},
+		{"Excel1900InInputTimezone", "45171.5", "Excel1900", "RFC3339", "America/Chicago", "2023-09-02T12:00:00-05:00", ""},
+		{"Excel1900BeforeLeapBug", "59", "Excel1900", "DateOnly", "", "1900-02-28", ""},
+		{"Excel1900AfterLeapBug", "61", "Excel1900", "DateOnly", "", "1900-03-01", ""},
+		{"Excel1900FirstDay", "1", "Excel1900", "DateOnly", "", "1900-01-01", ""},
+		{"Excel1900LeapBugDay", "60", "Excel1900", "DateOnly", "", "", "Unable to parse"},
+		{"Excel1900Invalid", "abc", "Excel1900", "DateOnly", "", "", "Unable to parse"},
+		{"RFC3339ToExcel1900", "2023-09-02T12:00:00Z", "RFC3339", "Excel1900", "", "45171.5", ""},
+		{"RFC3339ToExcel1900BeforeLeapBug", "1900-02-28T12:00:00Z", "RFC3339", "Excel1900", "", "59.5", ""},
+		{"Excel1904ToRFC3339", "43709.5", "Excel1904", "RFC3339", "", "2023-09-02T12:00:00Z", ""},
+		{"Excel1904Epoch", "0", "Excel1904", "DateOnly", "", "1904-01-01", ""},
+		{"RFC3339ToExcel1904", "2023-09-02T12:00:00Z", "RFC3339", "Excel1904", "", "43709.5", ""},
+		{"RFC3339ToExcel1904TooEarly", "1903-12-31T12:00:00Z", "RFC3339", "Excel1904", "", "", "can't represent"},
+		{"CocoaToRFC3339", "715342884.25", "Cocoa", "RFC3339Nano", "", "2023-09-02T10:21:24.25Z", ""},
+		{"CocoaNegative", "-1.5", "Cocoa", "RFC3339Nano", "", "2000-12-31T23:59:58.5Z", ""},
+		{"CocoaInvalid", "715342884.", "Cocoa", "RFC3339Nano", "", "", "Unable to parse"},
+		{"RFC3339ToCocoa", "2023-09-02T10:21:24.25Z", "RFC3339Nano", "Cocoa", "", "715342884.25", ""},
+		{"RFC3339ToCocoaNegative", "2000-12-31T23:59:58.5Z", "RFC3339Nano", "Cocoa", "", "-1.5", ""},
+		{"WebKitToRFC3339", "13338123684250000", "WebKit", "RFC3339Nano", "", "2023-09-02T10:21:24.25Z", ""},
+		{"RFC3339ToWebKit", "2023-09-02T10:21:24.25Z", "RFC3339Nano", "WebKit", "", "13338123684250000", ""},
+		{"NTPToRFC3339", "3902638884.25", "NTP", "RFC3339Nano", "", "2023-09-02T10:21:24.25Z", ""},
+		{"NTPNegative", "-1", "NTP", "RFC3339Nano", "", "", "Unable to parse"},
+		{"RFC3339ToNTP", "2023-09-02T10:21:24.25Z", "RFC3339Nano", "NTP", "", "3902638884.25", ""},
+		{"NTPHexToRFC3339", "E89D8B24.40000000", "NTPHex", "RFC3339Nano", "", "2023-09-02T10:21:24.25Z", ""},
+		{"NTPHexPacked", "0xe89d8b2440000000", "NTPHex", "RFC3339Nano", "", "2023-09-02T10:21:24.25Z", ""},
+		{"NTPHexNextEra", "00000001.00000000", "NTPHex", "RFC3339Nano", "", "2036-02-07T06:28:17Z", ""},
+		{"NTPHexInvalid", "E89D8B24", "NTPHex", "RFC3339Nano", "", "", "Unable to parse"},
+		{"RFC3339ToNTPHex", "2023-09-02T10:21:24.25Z", "RFC3339Nano", "NTPHex", "", "E89D8B24.40000000", ""},
+		{"RFC3339ToNTPHexNextEra", "2036-02-07T06:28:17Z", "RFC3339Nano", "NTPHex", "", "00000001.00000000", ""},
+		{"GPSToRFC3339", "2277:555702.25", "GPS", "RFC3339Nano", "", "2023-09-02T10:21:24.25Z", ""},
+		{"GPSEpoch", "0:0", "GPS", "RFC3339Nano", "", "1980-01-06T00:00:00Z", ""},
+		{"GPSBefore2017ToRFC3339", "1877:432017", "GPS", "RFC3339Nano", "", "2016-01-01T00:00:00Z", ""},
+		{"GPSIn1990ToRFC3339", "521:86406", "GPS", "RFC3339Nano", "", "1990-01-01T00:00:00Z", ""},
+		{"RFC3339ToGPSEpoch", "1980-01-06T00:00:00Z", "RFC3339", "GPS", "", "0:0", ""},
+		{"RFC3339ToGPSBefore2017", "2016-01-01T00:00:00Z", "RFC3339", "GPS", "", "1877:432017", ""},
+		{"RFC3339ToGPSIn1990", "1990-01-01T00:00:00Z", "RFC3339", "GPS", "", "521:86406", ""},
+		{"GPSSecondsOutOfRange", "2277:604800", "GPS", "RFC3339Nano", "", "", "Unable to parse"},
+		{"GPSInvalid", "2277", "GPS", "RFC3339Nano", "", "", "Unable to parse"},
+		{"RFC3339ToGPS", "2023-09-02T10:21:24.25Z", "RFC3339Nano", "GPS", "", "2277:555702.25", ""},
+		{"RFC3339ToGPSTooEarly", "1979-01-01T00:00:00Z", "RFC3339", "GPS", "", "", "before 1980-01-06"},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.InputTimeZone = ""
+		helpers.CmdHelpers.OutputTimeZone = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = test.inputFormatName
+			helpers.CmdHelpers.Value = test.value
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.InputTimeZone = test.inputTimezone
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+			if test.inputTimezone != "" {
+				helpers.CmdHelpers.OutputTimeZone = test.inputTimezone
+			}
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResult, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_Convert_IDFormats(t *testing.T) {
+	tests := []struct {
+		name                string
+		value               string
+		inputFormatName     string
+		outputFormatName    string
+		snowflakeLayoutName string
+		snowflakeEpoch      string
+		snowflakeShift      int
+		wantResult          string
+		wantErrString       string
+	}{
+		{"UUIDv1", "c232ab00-9414-11ec-b3c8-9f6bdeced846", "UUID", "RFC3339Nano", "", "", 0, "2022-02-22T19:22:22Z", ""},
+		{"UUIDv6", "1EC9414C-232A-6B00-B3C8-9F6BDECED846", "UUID", "RFC3339Nano", "", "", 0, "2022-02-22T19:22:22Z", ""},
+		{"UUIDv7", "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", "UUID", "RFC3339Nano", "", "", 0, "2022-02-22T19:22:22Z", ""},
+		{"UUIDv7Braces", "{017F22E2-79B0-7CC3-98C4-DC0C0C07398F}", "UUID", "RFC3339Nano", "", "", 0, "2022-02-22T19:22:22Z", ""},
+		{"UUIDv7URN", "urn:uuid:017f22e2-79b0-7cc3-98c4-dc0c0c07398f", "UUID", "RFC3339Nano", "", "", 0, "2022-02-22T19:22:22Z", ""},
+		{"UUIDv7NoHyphens", "017f22e279b07cc398c4dc0c0c07398f", "UUID", "RFC3339Nano", "", "", 0, "2022-02-22T19:22:22Z", ""},
+		{"UUIDv4", "f47ac10b-58cc-4372-a567-0e02b2c3d479", "UUID", "RFC3339Nano", "", "", 0, "", "Unable to parse"},
+		{"UUIDInvalid", "017f22e2-79b0-7cc3-98c4", "UUID", "RFC3339Nano", "", "", 0, "", "Unable to parse"},
+		{"ULID", "01ARZ3NDEKTSV4RRFFQ69G5FAV", "ULID", "RFC3339Nano", "", "", 0, "2016-07-30T23:54:10.259Z", ""},
+		{"ULIDLowercase", "01arz3ndektsv4rrffq69g5fav", "ULID", "RFC3339Nano", "", "", 0, "2016-07-30T23:54:10.259Z", ""},
+		{"ULIDInvalidChar", "01ARZ3NDEKTSV4RRFFQ69G5FAU", "ULID", "RFC3339Nano", "", "", 0, "", "Unable to parse"},
+		{"ULIDOverflow", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "ULID", "RFC3339Nano", "", "", 0, "", "Unable to parse"},
+		{"KSUID", "0ujtsYcgvSTl8PAuAdqWYSMnLOv", "KSUID", "RFC3339Nano", "", "", 0, "2017-10-10T04:00:47Z", ""},
+		{"KSUIDOverflow", "zzzzzzzzzzzzzzzzzzzzzzzzzzz", "KSUID", "RFC3339Nano", "", "", 0, "", "Unable to parse"},
+		{"ObjectID", "507f1f77bcf86cd799439011", "ObjectID", "RFC3339Nano", "", "", 0, "2012-10-17T21:13:27Z", ""},
+		{"ObjectIDInvalid", "507f1f77bcf86cd79943901", "ObjectID", "RFC3339Nano", "", "", 0, "", "Unable to parse"},
+		{"SnowflakeTwitterDefault", "1541815603606036480", "Snowflake", "RFC3339Nano", "", "", 0, "2022-06-28T16:07:40.105Z", ""},
+		{"SnowflakeDiscord", "175928847299117063", "Snowflake", "RFC3339Nano", "discord", "", 0, "2016-04-30T11:18:25.796Z", ""},
+		{"SnowflakeInstagram", "1380412232543993932", "Snowflake", "RFC3339Nano", "Instagram", "", 0, "2016-11-10T11:39:43.249Z", ""},
+		{"SnowflakeCustom", "175928847299117063", "Snowflake", "RFC3339Nano", "custom", "1420070400000", 22, "2016-04-30T11:18:25.796Z", ""},
+		{"SnowflakeCustomNoEpoch", "175928847299117063", "Snowflake", "RFC3339Nano", "custom", "", 22, "", "requires a snowflake-epoch"},
+		{"SnowflakeCustomBadShift", "175928847299117063", "Snowflake", "RFC3339Nano", "custom", "0", 64, "", "Invalid snowflake-shift"},
+		{"SnowflakeEpochWithPreset", "175928847299117063", "Snowflake", "RFC3339Nano", "discord", "0", 0, "", "only used with the custom"},
+		{"SnowflakeShiftWithPreset", "1541815603606036480", "Snowflake", "RFC3339Nano", "twitter", "", 12, "", "A snowflake-shift value is only used with the custom"},
+		{"SnowflakeUnknownLayout", "175928847299117063", "Snowflake", "RFC3339Nano", "myspace", "", 0, "", "Unknown snowflake layout"},
+		{"SnowflakeInvalid", "abc", "Snowflake", "RFC3339Nano", "", "", 0, "", "Unable to parse"},
+		{"IDOutputFormat", "2023-09-02T10:21:24Z", "RFC3339", "ULID", "", "", 0, "", "only supported as an input format"},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.SnowflakeLayoutName = ""
+		helpers.CmdHelpers.SnowflakeEpochMillis = ""
+		helpers.CmdHelpers.SnowflakeTimestampShift = helpers.DefaultSnowflakeTimestampShift
+		helpers.CmdHelpers.SnowflakeTimestampShiftProvided = false
+		helpers.CmdHelpers.OutputTimeZone = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = test.inputFormatName
+			helpers.CmdHelpers.Value = test.value
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+			helpers.CmdHelpers.SnowflakeLayoutName = test.snowflakeLayoutName
+			helpers.CmdHelpers.SnowflakeEpochMillis = test.snowflakeEpoch
+			helpers.CmdHelpers.SnowflakeTimestampShift = test.snowflakeShift
+			// a shift of 0 stands for the flag not being provided
+			helpers.CmdHelpers.SnowflakeTimestampShiftProvided = test.snowflakeShift != 0
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResult, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_Convert_IDBounds(t *testing.T) {
+	tests := []struct {
+		name                string
+		value               string
+		inputFormatName     string
+		outputFormatName    string
+		snowflakeLayoutName string
+		snowflakeEpoch      string
+		snowflakeShift      int
+		wantResult          string
+		wantErrString       string
+	}{
+		{"UUIDv7Min", "2022-02-22T19:22:22.0009Z", "RFC3339Nano", "UUIDv7Min", "", "", 0, "017f22e2-79b0-7000-8000-000000000000", ""},
+		{"UUIDv7Max", "2022-02-22T19:22:22Z", "RFC3339Nano", "UUIDv7Max", "", "", 0, "017f22e2-79b0-7fff-bfff-ffffffffffff", ""},
+		{"UUIDv7BeforeUnixEpoch", "1969-12-31T23:59:59Z", "RFC3339Nano", "UUIDv7Min", "", "", 0, "", "can't represent"},
+		{"ULIDMin", "2022-02-22T19:22:22Z", "RFC3339Nano", "ULIDMin", "", "", 0, "01FWHE4YDG0000000000000000", ""},
+		{"ULIDMax", "2022-02-22T19:22:22Z", "RFC3339Nano", "ULIDMax", "", "", 0, "01FWHE4YDGZZZZZZZZZZZZZZZZ", ""},
+		{"SnowflakeMinTwitter", "2022-06-28T16:07:40.105Z", "RFC3339Nano", "SnowflakeMin", "", "", 0, "1541815603604488192", ""},
+		{"SnowflakeMaxTwitter", "2022-06-28T16:07:40.105Z", "RFC3339Nano", "SnowflakeMax", "", "", 0, "1541815603608682495", ""},
+		{"SnowflakeMinDiscord", "2016-04-30T11:18:25.796Z", "RFC3339Nano", "SnowflakeMin", "discord", "", 0, "175928847298985984", ""},
+		{"SnowflakeMaxCustom", "1970-01-01T00:00:00.002Z", "RFC3339Nano", "SnowflakeMax", "custom", "0", 12, "12287", ""},
+		{"SnowflakeBeforeEpoch", "2009-01-01T00:00:00Z", "RFC3339Nano", "SnowflakeMin", "", "", 0, "", "can't represent"},
+		{"BoundAsInputFormat", "017f22e2-79b0-7000-8000-000000000000", "UUIDv7Min", "RFC3339", "", "", 0, "", "only supported as an output format"},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.SnowflakeLayoutName = ""
+		helpers.CmdHelpers.SnowflakeEpochMillis = ""
+		helpers.CmdHelpers.SnowflakeTimestampShift = helpers.DefaultSnowflakeTimestampShift
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = test.inputFormatName
+			helpers.CmdHelpers.Value = test.value
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.SnowflakeLayoutName = test.snowflakeLayoutName
+			helpers.CmdHelpers.SnowflakeEpochMillis = test.snowflakeEpoch
+			helpers.CmdHelpers.SnowflakeTimestampShift = test.snowflakeShift
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResult, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_Convert_UnixVariants(t *testing.T) {
+	tests := []struct {
+		name             string
+		value            string
+		inputFormatName  string
+		outputFormatName string
+		precision        int
+		wantResult       string
+		wantErrString    string
+	}{
+		{"FloatToRFC3339", "1693668084.123456", "UnixSecsFloat", "RFC3339Nano", 6, "2023-09-02T15:21:24.123456Z", ""},
+		{"FloatNanos", "1693668084.123456789", "UnixSecsFloat", "RFC3339Nano", 6, "2023-09-02T15:21:24.123456789Z", ""},
+		{"FloatWithoutFraction", "1693668084", "UnixSecsFloat", "RFC3339Nano", 6, "2023-09-02T15:21:24Z", ""},
+		{"FloatNegative", "-1.5", "UnixSecsFloat", "RFC3339Nano", 6, "1969-12-31T23:59:58.5Z", ""},
+		{"FloatDetected", "1693668084.25", "Auto", "RFC3339Nano", 6, "2023-09-02T15:21:24.25Z", ""},
+		{"FloatInvalid", "1693668084.12.3", "UnixSecsFloat", "RFC3339Nano", 6, "", "Unable to parse"},
+		{"RFC3339ToFloat", "2023-09-02T15:21:24.123456789Z", "RFC3339Nano", "UnixSecsFloat", 6, "1693668084.123456", ""},
+		{"RFC3339ToFloatPrecision3", "2023-09-02T15:21:24.123956789Z", "RFC3339Nano", "UnixSecsFloat", 3, "1693668084.123", ""},
+		{"RFC3339ToFloatPrecision9", "2023-09-02T15:21:24.123456789Z", "RFC3339Nano", "UnixSecsFloat", 9, "1693668084.123456789", ""},
+		{"RFC3339ToFloatPrecision0", "2023-09-02T15:21:24.9Z", "RFC3339Nano", "UnixSecsFloat", 0, "1693668084", ""},
+		{"RFC3339ToFloatNegative", "1969-12-31T23:59:58.5Z", "RFC3339Nano", "UnixSecsFloat", 3, "-1.500", ""},
+		{"InvalidPrecision", "2023-09-02T15:21:24Z", "RFC3339Nano", "UnixSecsFloat", 10, "", "Invalid precision value"},
+		{"SecsHexToRFC3339", "0x64F352F4", "UnixSecsHex", "RFC3339Nano", 6, "2023-09-02T15:21:24Z", ""},
+		{"SecsHexNoPrefix", "64f352f4", "UnixSecsHex", "RFC3339Nano", 6, "2023-09-02T15:21:24Z", ""},
+		{"SecsHexInvalid", "0x64F352G4", "UnixSecsHex", "RFC3339Nano", 6, "", "Unable to parse"},
+		{"MilliHexToRFC3339", "0x18A567C099B", "UnixMilliHex", "RFC3339Nano", 6, "2023-09-02T15:21:24.123Z", ""},
+		{"MicroHexToRFC3339", "0x60461D4858740", "UnixMicroHex", "RFC3339Nano", 6, "2023-09-02T15:21:24.123456Z", ""},
+		{"NanoHexToRFC3339", "0x17811E2629985515", "UnixNanoHex", "RFC3339Nano", 6, "2023-09-02T15:21:24.123456789Z", ""},
+		{"RFC3339ToSecsHex", "2023-09-02T15:21:24.5Z", "RFC3339Nano", "UnixSecsHex", 6, "0x64F352F4", ""},
+		{"RFC3339ToMilliHex", "2023-09-02T15:21:24.123Z", "RFC3339Nano", "UnixMilliHex", 6, "0x18A567C099B", ""},
+		{"RFC3339ToNanoHex", "2023-09-02T15:21:24.123456789Z", "RFC3339Nano", "UnixNanoHex", 6, "0x17811E2629985515", ""},
+		{"RFC3339ToHexBeforeEpoch", "1969-12-31T23:59:59Z", "RFC3339Nano", "UnixSecsHex", 6, "", "before the Unix epoch"},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.UnixFloatPrecision = helpers.DefaultUnixFloatPrecision
+		helpers.CmdHelpers.OutputTimeZone = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = test.inputFormatName
+			helpers.CmdHelpers.Value = test.value
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+			helpers.CmdHelpers.UnixFloatPrecision = test.precision
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResult, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_Convert_TimeScales(t *testing.T) {
+	tests := []struct {
+		name                string
+		value               string
+		inputFormatName     string
+		outputFormatName    string
+		timeScaleName       string
+		inputTimeScaleName  string
+		outputTimeScaleName string
+		wantResult          string
+		wantErrString       string
+	}{
+		{"UTCToTAI", "2023-09-02T15:21:24Z", "RFC3339", "RFC3339", "", "", "tai", "2023-09-02T15:22:01Z", ""},
+		{"UTCToGPS", "2023-09-02T15:21:24Z", "RFC3339", "RFC3339", "", "", "gps", "2023-09-02T15:21:42Z", ""},
+		{"TAIToUTC", "2023-09-02T15:22:01Z", "RFC3339", "RFC3339", "", "tai", "", "2023-09-02T15:21:24Z", ""},
+		{"GPSToTAI", "2023-09-02T15:21:42Z", "RFC3339", "RFC3339", "", "gps", "tai", "2023-09-02T15:22:01Z", ""},
+		{"TimeScaleBoth", "2023-09-02T15:22:01Z", "RFC3339", "RFC3339", "tai", "", "", "2023-09-02T15:22:01Z", ""},
+		{"TimeScaleOverridden", "2023-09-02T15:22:01Z", "RFC3339", "RFC3339", "tai", "", "utc", "2023-09-02T15:21:24Z", ""},
+		{"UTCToTAIBefore1980", "1979-06-01T00:00:00Z", "RFC3339", "RFC3339", "", "", "tai", "1979-06-01T00:00:18Z", ""},
+		{"UnixSecsToTAI", "1693668084", "UnixSecs", "UnixSecs", "", "", "tai", "1693668121", ""},
+		{"BeforeLeapSecondToTAI", "2016-12-31T23:59:59Z", "RFC3339", "RFC3339", "", "", "tai", "2017-01-01T00:00:35Z", ""},
+		{"LeapSecondToTAI", "2016-12-31T23:59:60Z", "RFC3339", "RFC3339", "", "", "tai", "2017-01-01T00:00:36Z", ""},
+		{"LeapSecondFractionToTAI", "2016-12-31T23:59:60.5Z", "RFC3339Nano", "RFC3339Nano", "", "", "tai", "2017-01-01T00:00:36.5Z", ""},
+		{"AfterLeapSecondToTAI", "2017-01-01T00:00:00Z", "RFC3339", "RFC3339", "", "", "tai", "2017-01-01T00:00:37Z", ""},
+		{"LeapSecondWithOffsetToGPS", "2016-12-31T18:59:60-05:00", "RFC3339", "RFC3339", "", "", "gps", "2017-01-01T00:00:17Z", ""},
+		{"LeapSecondWithHalfHourOffsetToTAI", "2017-01-01T05:29:60+05:30", "RFC3339", "RFC3339", "", "", "tai", "2017-01-01T00:00:36Z", ""},
+		{"NotALeapSecondWithHalfHourOffset", "2017-01-01T05:59:60+05:30", "RFC3339", "RFC3339", "", "", "tai", "", "Unable to parse"},
+		{"LeapSecondDetected", "2015-06-30T23:59:60Z", "Auto", "RFC3339", "", "", "tai", "2015-07-01T00:00:35Z", ""},
+		{"LeapSecondToUTC", "2016-12-31T23:59:60Z", "RFC3339", "RFC3339", "", "", "", "2016-12-31T23:59:59Z", ""},
+		{"TAILeapSecondToUTC", "2017-01-01T00:00:36Z", "RFC3339", "RFC3339", "", "tai", "", "2016-12-31T23:59:59Z", ""},
+		{"TAILeapSecondToGPS", "2017-01-01T00:00:36Z", "RFC3339", "RFC3339", "", "tai", "gps", "2017-01-01T00:00:17Z", ""},
+		{"NotALeapSecond", "2015-12-31T23:59:60Z", "RFC3339", "RFC3339", "", "", "tai", "", "Unable to parse"},
+		{"LeapSecondOnTAIScale", "2016-12-31T23:59:60Z", "RFC3339", "RFC3339", "", "tai", "", "", "Unable to parse"},
+		{"GPSFormatIgnoresTimeScale", "2023-09-02T15:21:24Z", "RFC3339", "GPS", "", "", "gps", "2277:573702", ""},
+		{"GPSFormatBefore2017", "2016-01-01T00:00:00Z", "RFC3339", "GPS", "", "", "", "1877:432017", ""},
+		{"BeforeLeapSecondToGPSFormat", "2016-12-31T23:59:59Z", "RFC3339", "GPS", "", "", "", "1930:16", ""},
+		{"LeapSecondToGPSFormat", "2016-12-31T23:59:60Z", "RFC3339", "GPS", "", "", "", "1930:17", ""},
+		{"AfterLeapSecondToGPSFormat", "2017-01-01T00:00:00Z", "RFC3339", "GPS", "", "", "", "1930:18", ""},
+		{"InvalidTimeScale", "2023-09-02T15:21:24Z", "RFC3339", "RFC3339", "", "", "tt", "", "Unknown time scale"},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.TimeScaleName = ""
+		helpers.CmdHelpers.InputTimeScaleName = ""
+		helpers.CmdHelpers.OutputTimeScaleName = ""
+		helpers.CmdHelpers.OutputTimeZone = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = test.inputFormatName
+			helpers.CmdHelpers.Value = test.value
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+			helpers.CmdHelpers.TimeScaleName = test.timeScaleName
+			helpers.CmdHelpers.InputTimeScaleName = test.inputTimeScaleName
+			helpers.CmdHelpers.OutputTimeScaleName = test.outputTimeScaleName
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResult, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
+
+func TestTimeConverter_Convert_DayCountFormats(t *testing.T) {
+	tests := []struct {
+		name             string
+		value            string
+		inputFormatName  string
+		outputFormatName string
+		precision        int
+		wantResult       string
+		wantErrString    string
+	}{
+		{"RFC3339ToJulianDay", "2023-09-02T10:21:24Z", "RFC3339", "JulianDay", 6, "2460189.931527", ""},
+		{"RFC3339ToJulianDayJ2000", "2000-01-01T12:00:00Z", "RFC3339", "JulianDay", 1, "2451545.0", ""},
+		{"RFC3339ToModifiedJulianDay", "2023-09-02T10:21:24Z", "RFC3339", "ModifiedJulianDay", 6, "60189.431527", ""},
+		{"RFC3339ToModifiedJulianDayEpoch", "1858-11-17T00:00:00Z", "RFC3339", "ModifiedJulianDay", 3, "0.000", ""},
+		{"RFC3339ToModifiedJulianDayNegative", "1858-11-15T18:00:00Z", "RFC3339", "ModifiedJulianDay", 2, "-1.25", ""},
+		{"RFC3339ToRataDie", "2023-09-02T10:21:24Z", "RFC3339", "RataDie", 6, "738765.431527", ""},
+		{"RFC3339ToRataDieDayOne", "0001-01-01T00:00:00Z", "RFC3339", "RataDie", 0, "1", ""},
+		{"RFC3339ToDecimalYear", "2023-09-02T10:21:24Z", "RFC3339", "DecimalYear", 6, "2023.669675", ""},
+		{"RFC3339ToDecimalYearLeapYear", "2024-07-02T00:00:00Z", "RFC3339", "DecimalYear", 3, "2024.500", ""},
+		{"RFC3339ToJulianDayPrecision9", "2023-09-02T10:21:24Z", "RFC3339", "JulianDay", 9, "2460189.931527777", ""},
+		{"JulianDayToRFC3339", "2460189.9315277777", "JulianDay", "RFC3339Nano", 6, "2023-09-02T10:21:23.99999328Z", ""},
+		{"JulianDayWholeDayToRFC3339", "2451545", "JulianDay", "RFC3339Nano", 6, "2000-01-01T12:00:00Z", ""},
+		{"ModifiedJulianDayToRFC3339", "60189.5", "ModifiedJulianDay", "RFC3339Nano", 6, "2023-09-02T12:00:00Z", ""},
+		{"ModifiedJulianDayNegative", "-1.25", "ModifiedJulianDay", "RFC3339Nano", 6, "1858-11-15T18:00:00Z", ""},
+		{"RataDieToRFC3339", "738765.25", "RataDie", "RFC3339Nano", 6, "2023-09-02T06:00:00Z", ""},
+		{"DecimalYearToRFC3339", "2024.5", "DecimalYear", "RFC3339Nano", 6, "2024-07-02T00:00:00Z", ""},
+		{"DecimalYearWholeYear", "2023", "DecimalYear", "RFC3339Nano", 6, "2023-01-01T00:00:00Z", ""},
+		{"JulianDayInvalid", "2460189.9.3", "JulianDay", "RFC3339Nano", 6, "", "Unable to parse"},
+		{"JulianDayExponent", "2.46e6", "JulianDay", "RFC3339Nano", 6, "", "Unable to parse"},
+		{"DecimalYearOutOfRange", "99999999999.5", "DecimalYear", "RFC3339Nano", 6, "", "Unable to parse"},
+	}
+
+	defer func() {
+		helpers.CmdHelpers.UnixFloatPrecision = helpers.DefaultUnixFloatPrecision
+		helpers.CmdHelpers.OutputTimeZone = ""
+		helpers.ExitCode = helpers.ExitCodeSuccess
+	}()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			helpers.CmdHelpers.InputFormatName = test.inputFormatName
+			helpers.CmdHelpers.Value = test.value
+			helpers.CmdHelpers.OutputFormatName = test.outputFormatName
+			helpers.CmdHelpers.OutputTimeZone = "UTC"
+			helpers.CmdHelpers.UnixFloatPrecision = test.precision
+
+			err := New().Convert(true)
+			if test.wantErrString != "" {
+				assert.NotNil(t, err)
+				assert.Contains(t, err.Error(), test.wantErrString)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResult, helpers.CmdHelpers.ConvertedResult)
+		})
+	}
+}
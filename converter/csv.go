@@ -0,0 +1,297 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package converter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/hobysmith/timeconverter/helpers"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// csvField is a single field from a CSV record.  The raw text is kept so that fields which are
+// not converted are written back out exactly as they were read, including their quoting.
+type csvField struct {
+	raw    string
+	value  string
+	quoted bool
+}
+
+// ConvertCSV reads CSV records from input, converts the values in the columns listed in CmdHelpers.CSVColumns,
+// and writes the records to output.  Fields that are not converted are written out unchanged.
+//
+// Columns can be referenced by header name, when CmdHelpers.CSVHasHeader is true, or by 1-based index.
+// Fields that fail to convert are handled according to CmdHelpers.BatchErrorMode, the same as batch mode.
+// ResolveFormats must be called before calling ConvertCSV.
+func (tfd *TimeConverter) ConvertCSV(input io.Reader, output io.Writer) error {
+	if err := tfd.requireSingleOutputFormat(); err != nil {
+		return err
+	}
+
+	batchErrorMode, found := helpers.BatchErrorModeNameToMode[strings.ToLower(helpers.CmdHelpers.BatchErrorModeName)]
+	if !found {
+		return fmt.Errorf("Unknown batch-errors mode: %s", helpers.CmdHelpers.BatchErrorModeName)
+	}
+
+	delimiter, err := parseCSVDelimiter(helpers.CmdHelpers.CSVDelimiter)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(helpers.CmdHelpers.CSVColumns) == "" {
+		return errors.New("No columns provided. Use --columns to list the columns to convert.")
+	}
+
+	reader := bufio.NewReader(input)
+	recordNum := 0
+	var columnIndexes map[int]bool
+
+	for {
+		fields, terminator, err := readCSVRecord(reader, delimiter)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			helpers.ExitCode = helpers.ExitCodeFailureReadingPipeInput
+			return fmt.Errorf("Failure reading CSV record %d: %s", recordNum+1, err)
+		}
+		recordNum++
+
+		if columnIndexes == nil {
+			var headerFields []csvField
+			if helpers.CmdHelpers.CSVHasHeader {
+				headerFields = fields
+			}
+
+			columnIndexes, err = resolveCSVColumns(helpers.CmdHelpers.CSVColumns, headerFields)
+			if err != nil {
+				return err
+			}
+
+			if helpers.CmdHelpers.CSVHasHeader {
+				if err = writeCSVRecord(output, fields, delimiter, terminator); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		for fieldIdx := range fields {
+			if !columnIndexes[fieldIdx] {
+				continue
+			}
+
+			inputVal := strings.TrimSpace(fields[fieldIdx].value)
+			if inputVal == "" {
+				continue
+			}
+
+			convertedResult, err := tfd.ConvertValue(inputVal)
+			if err != nil {
+				switch batchErrorMode {
+				case helpers.BatchErrorMode_PassThrough:
+					helpers.ExitCode = helpers.ExitCodeSuccess
+					continue
+				case helpers.BatchErrorMode_Marker:
+					helpers.ExitCode = helpers.ExitCodeSuccess
+					convertedResult = helpers.BatchErrorMarker
+				default:
+					if helpers.ExitCode == helpers.ExitCodeSuccess {
+						helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+					}
+					return fmt.Errorf("Record %d, column %d: %s", recordNum, fieldIdx+1, err)
+				}
+			}
+
+			fields[fieldIdx] = newCSVField(convertedResult, fields[fieldIdx].quoted, delimiter)
+		}
+
+		if err = writeCSVRecord(output, fields, delimiter, terminator); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseCSVDelimiter accepts a single character delimiter.  Since tabs are awkward to pass on
+// the commandline, "\t" and "tab" are also accepted.
+func parseCSVDelimiter(delimiterText string) (rune, error) {
+	switch strings.ToLower(delimiterText) {
+	case "":
+		return ',', nil
+	case `\t`, "tab":
+		return '\t', nil
+	}
+
+	delimiter, size := utf8.DecodeRuneInString(delimiterText)
+	if size != len(delimiterText) || delimiter == '"' || delimiter == '\r' || delimiter == '\n' {
+		return 0, fmt.Errorf("Invalid delimiter \"%s\". The delimiter must be a single character other than a quote or newline.", delimiterText)
+	}
+
+	return delimiter, nil
+}
+
+// resolveCSVColumns maps the comma separated list of column references to field indexes.
+// A reference is first matched against the header names, if there is a header, then as a 1-based column number.
+func resolveCSVColumns(columnsText string, headerFields []csvField) (map[int]bool, error) {
+	columnIndexes := make(map[int]bool)
+
+	for _, columnRef := range strings.Split(columnsText, ",") {
+		columnRef = strings.TrimSpace(columnRef)
+		if columnRef == "" {
+			continue
+		}
+
+		found := false
+		for headerIdx, headerField := range headerFields {
+			if strings.TrimSpace(headerField.value) == columnRef {
+				columnIndexes[headerIdx] = true
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+
+		columnNum, err := strconv.Atoi(columnRef)
+		if err != nil || columnNum < 1 {
+			if headerFields == nil {
+				return nil, fmt.Errorf("Unknown column \"%s\". Without a header, columns must be referenced by number, starting at 1.", columnRef)
+			}
+			return nil, fmt.Errorf("Unknown column \"%s\". It is not a header name or a column number.", columnRef)
+		}
+
+		columnIndexes[columnNum-1] = true
+	}
+
+	return columnIndexes, nil
+}
+
+// readCSVRecord reads one record from the reader, which may span several lines when quoted fields
+// contain newlines.  It returns the fields and the line terminator that ended the record, which is
+// empty when the record ends at EOF.  io.EOF is returned when there are no more records.
+func readCSVRecord(reader *bufio.Reader, delimiter rune) (fields []csvField, terminator string, err error) {
+	var field csvField
+	var rawBuilder, valueBuilder strings.Builder
+	fieldStarted := false
+	inQuotes := false
+	recordStarted := false
+
+	endField := func() {
+		field.raw = rawBuilder.String()
+		field.value = valueBuilder.String()
+		fields = append(fields, field)
+		field = csvField{}
+		rawBuilder.Reset()
+		valueBuilder.Reset()
+		fieldStarted = false
+	}
+
+	for {
+		char, _, readErr := reader.ReadRune()
+		if readErr == io.EOF {
+			if !recordStarted {
+				return nil, "", io.EOF
+			}
+			if inQuotes {
+				return nil, "", errors.New("Unterminated quoted field")
+			}
+			endField()
+			return fields, "", nil
+		}
+		if readErr != nil {
+			return nil, "", readErr
+		}
+		recordStarted = true
+
+		if inQuotes {
+			rawBuilder.WriteRune(char)
+			if char != '"' {
+				valueBuilder.WriteRune(char)
+				continue
+			}
+
+			// A doubled quote is an escaped quote.  Otherwise, this closes the quoted text.
+			nextChar, _, peekErr := reader.ReadRune()
+			if peekErr == nil && nextChar == '"' {
+				rawBuilder.WriteRune(nextChar)
+				valueBuilder.WriteRune(nextChar)
+				continue
+			}
+			if peekErr == nil {
+				_ = reader.UnreadRune()
+			}
+			inQuotes = false
+			continue
+		}
+
+		switch char {
+		case delimiter:
+			endField()
+			continue
+		case '\n':
+			endField()
+			return fields, "\n", nil
+		case '\r':
+			nextChar, _, peekErr := reader.ReadRune()
+			if peekErr == nil && nextChar == '\n' {
+				endField()
+				return fields, "\r\n", nil
+			}
+			if peekErr == nil {
+				_ = reader.UnreadRune()
+			}
+			rawBuilder.WriteRune(char)
+			valueBuilder.WriteRune(char)
+		case '"':
+			rawBuilder.WriteRune(char)
+			if !fieldStarted {
+				field.quoted = true
+				inQuotes = true
+			} else {
+				// a quote in the middle of an unquoted field is kept as is
+				valueBuilder.WriteRune(char)
+			}
+		default:
+			rawBuilder.WriteRune(char)
+			valueBuilder.WriteRune(char)
+		}
+		fieldStarted = true
+	}
+}
+
+// newCSVField builds a field for a converted value.  The value is quoted if the original field was quoted,
+// or if the value contains characters that require quoting.
+func newCSVField(value string, quoted bool, delimiter rune) csvField {
+	if !quoted && !strings.ContainsAny(value, string(delimiter)+"\"\r\n") {
+		return csvField{raw: value, value: value}
+	}
+
+	return csvField{
+		raw:    `"` + strings.ReplaceAll(value, `"`, `""`) + `"`,
+		value:  value,
+		quoted: true,
+	}
+}
+
+func writeCSVRecord(output io.Writer, fields []csvField, delimiter rune, terminator string) error {
+	var recordBuilder strings.Builder
+	for fieldIdx, field := range fields {
+		if fieldIdx > 0 {
+			recordBuilder.WriteRune(delimiter)
+		}
+		recordBuilder.WriteString(field.raw)
+	}
+	recordBuilder.WriteString(terminator)
+
+	_, err := io.WriteString(output, recordBuilder.String())
+	return err
+}
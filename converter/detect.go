@@ -0,0 +1,128 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package converter
+
+import (
+	"fmt"
+	"github.com/hobysmith/timeconverter/helpers"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unixValueRegex matches input values that can only be interpreted as a Unix time value
+var unixValueRegex = regexp.MustCompile(`^[+-]?\d+$`)
+
+// unixFloatValueRegex matches input values that can only be interpreted as Unix seconds with a fraction
+var unixFloatValueRegex = regexp.MustCompile(`^[+-]?\d+\.\d+$`)
+
+// formatCandidate is a format that successfully parsed an input value during detection
+type formatCandidate struct {
+	format     helpers.TimeFormat
+	parsedTime time.Time
+}
+
+// DetectInputTime attempts to parse the input value with every format in TimeFormatToLayout, as well
+// as the Unix formats, the ISO week and ordinal dates, and ISO8601, and returns the parsed time along with the format that matched.
+//
+// Values that are only digits are treated as Unix time, and the unit is chosen by the magnitude of the value.
+// Values that are digits with a decimal fraction are treated as Unix seconds.
+// If several formats parse the value but disagree on the resulting time, such as USDate vs EUDate,
+// the value is ambiguous and an error listing the candidate formats is returned.  The exception is when USDateTimeZ
+// is one of them.  It was the default input format, and is the default output format, so it is chosen.
+func (tfd *TimeConverter) DetectInputTime(inputTimeText string) (convertTime time.Time, detectedFormat helpers.TimeFormat, err error) {
+	if unixValueRegex.MatchString(inputTimeText) {
+		inputUnixInt, err := strconv.ParseInt(inputTimeText, 10, 64)
+		if err != nil {
+			helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+			return time.Time{}, helpers.TimeFormat_Auto, err
+		}
+
+		detectedFormat = detectUnixTimeFormat(inputUnixInt)
+		convertTime, err = tfd.ParseInputTime(inputTimeText, detectedFormat)
+		return convertTime, detectedFormat, err
+	}
+
+	if unixFloatValueRegex.MatchString(inputTimeText) {
+		convertTime, err = tfd.ParseInputTime(inputTimeText, helpers.TimeFormat_UnixSecsFloat)
+		return convertTime, helpers.TimeFormat_UnixSecsFloat, err
+	}
+
+	// Map iteration is random, so sort the formats to make detection results repeatable
+	var layoutFormats []helpers.TimeFormat
+	for format := range helpers.TimeFormatToLayout {
+		layoutFormats = append(layoutFormats, format)
+	}
+	layoutFormats = append(layoutFormats, helpers.TimeFormat_ISOWeekDate, helpers.TimeFormat_ISOOrdinalDate, helpers.TimeFormat_ISO8601)
+	sort.Slice(layoutFormats, func(i, j int) bool { return layoutFormats[i] < layoutFormats[j] })
+
+	var candidates []formatCandidate
+	for _, format := range layoutFormats {
+		parsedTime, parseErr := tfd.ParseInputTime(inputTimeText, format)
+		if parseErr != nil {
+			continue
+		}
+		candidates = append(candidates, formatCandidate{format: format, parsedTime: parsedTime})
+	}
+
+	if len(candidates) == 0 {
+		helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+		return time.Time{}, helpers.TimeFormat_Auto, fmt.Errorf(
+			"Unable to detect a format for input value \"%s\". Use --input-format to specify the format.",
+			inputTimeText,
+		)
+	}
+
+	// Formats that resolve to the same instant are not really ambiguous, like RFC3339 and RFC3339Nano.
+	// In that case, the first (lowest ordered) format is reported.
+	for _, candidate := range candidates[1:] {
+		if !candidate.parsedTime.Equal(candidates[0].parsedTime) {
+			for _, defaultCandidate := range candidates {
+				if defaultCandidate.format == helpers.TimeFormat_USDateTimeZ {
+					return defaultCandidate.parsedTime, defaultCandidate.format, nil
+				}
+			}
+
+			var candidateDescs []string
+			for _, ambiguousCandidate := range candidates {
+				candidateDescs = append(candidateDescs, fmt.Sprintf(
+					"%s (%s)",
+					helpers.TimeFormatToName[ambiguousCandidate.format],
+					ambiguousCandidate.parsedTime.Format(time.RFC3339Nano),
+				))
+			}
+
+			helpers.ExitCode = helpers.ExitCodeAmbiguousInputFormat
+			return time.Time{}, helpers.TimeFormat_Auto, fmt.Errorf(
+				"Input value \"%s\" is ambiguous. It matches these formats: %s. Use --input-format to specify the format.",
+				inputTimeText,
+				strings.Join(candidateDescs, ", "),
+			)
+		}
+	}
+
+	return candidates[0].parsedTime, candidates[0].format, nil
+}
+
+// detectUnixTimeFormat chooses the Unix time unit based on the number of digits in the value.
+// Ten digits or fewer are seconds, 13 are milliseconds, 16 are microseconds and 19 are nanoseconds.
+func detectUnixTimeFormat(unixVal int64) helpers.TimeFormat {
+	if unixVal < 0 {
+		unixVal = -unixVal
+	}
+
+	switch {
+	case unixVal < 1e11:
+		return helpers.TimeFormat_Unix_Secs
+	case unixVal < 1e14:
+		return helpers.TimeFormat_Unix_Milli
+	case unixVal < 1e17:
+		return helpers.TimeFormat_Unix_Micro
+	default:
+		return helpers.TimeFormat_Unix_Nano
+	}
+}
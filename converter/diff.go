@@ -0,0 +1,121 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hobysmith/timeconverter/helpers"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Diff parses CmdHelpers.Value and CmdHelpers.DiffToValue, and stores the time elapsed from the first value
+// to the second in CmdHelpers.ConvertedResult, using the style in CmdHelpers.DiffStyleName.
+// The result is negative if the second value is before the first.
+//
+// The first value is read with the input format.  The second value is read with DiffToInputFormatName
+// and DiffToInputLayout, or with the input format and layout when those are not provided.
+// Note that the parameter fullQuiet means NOTHING should be output from this app.
+func (tfd *TimeConverter) Diff(fullQuiet bool) error {
+	diffStyle, found := helpers.DiffStyleNameToStyle[strings.ToLower(helpers.CmdHelpers.DiffStyleName)]
+	if !found {
+		return fmt.Errorf("Unknown diff style: %s", helpers.CmdHelpers.DiffStyleName)
+	}
+
+	if err := tfd.ResolveFormats(); err != nil {
+		return err
+	}
+
+	helpers.CmdHelpers.DiffToInputFormat = helpers.CmdHelpers.InputFormat
+	if helpers.CmdHelpers.DiffToInputFormatName != "" {
+		toInputFormat, err := resolveInputFormatName(helpers.CmdHelpers.DiffToInputFormatName, "to-input-format")
+		if err != nil {
+			return err
+		}
+
+		helpers.CmdHelpers.DiffToInputFormat = toInputFormat
+	}
+
+	if helpers.CmdHelpers.Value == "" || helpers.CmdHelpers.DiffToValue == "" {
+		helpers.ExitCode = helpers.ExitCodeErrorNoInputProvided
+		return errors.New("Diff requires two input values")
+	}
+
+	fromTime, err := tfd.parseValueWithFormat(helpers.CmdHelpers.Value, helpers.CmdHelpers.InputFormat, helpers.CmdHelpers.InputLayout)
+	if err != nil {
+		return err
+	}
+
+	toLayout := helpers.CmdHelpers.InputLayout
+	if helpers.CmdHelpers.DiffToInputLayout != "" {
+		toLayout = helpers.CmdHelpers.DiffToInputLayout
+	}
+
+	toTime, err := tfd.parseValueWithFormat(helpers.CmdHelpers.DiffToValue, helpers.CmdHelpers.DiffToInputFormat, toLayout)
+	if err != nil {
+		return err
+	}
+
+	helpers.CmdHelpers.ConvertedResult = FormatDiff(fromTime, toTime, diffStyle)
+
+	if fullQuiet {
+		return nil
+	}
+
+	if helpers.CmdHelpers.OutputValueOnly {
+		helpers.OP.Printf(helpers.OutputMode_Force, "%s\n", helpers.CmdHelpers.ConvertedResult)
+	} else {
+		helpers.OP.Printf(helpers.OutputMode_Force, "Difference: %s\n", helpers.CmdHelpers.ConvertedResult)
+	}
+
+	return nil
+}
+
+// parseValueWithFormat parses a value using the indicated format and layout, rather than the input format.  The input
+// format and layout in CmdHelpers are swapped for the duration of the parse, so that relative expressions and custom
+// layouts work for values like the second diff value.
+func (tfd *TimeConverter) parseValueWithFormat(inputVal string, inputFormat helpers.TimeFormat, inputLayout string) (time.Time, error) {
+	savedFormat, savedLayout := helpers.CmdHelpers.InputFormat, helpers.CmdHelpers.InputLayout
+	helpers.CmdHelpers.InputFormat, helpers.CmdHelpers.InputLayout = inputFormat, inputLayout
+	defer func() {
+		helpers.CmdHelpers.InputFormat, helpers.CmdHelpers.InputLayout = savedFormat, savedLayout
+	}()
+
+	parsedTime, err := tfd.ParseInputValue(inputVal)
+	if err != nil {
+		if inputFormat == helpers.TimeFormat_Auto {
+			return time.Time{}, err
+		}
+
+		return time.Time{}, fmt.Errorf(
+			"Unable to parse \"%s\" using format %s. Input value or format is not correct.",
+			inputVal,
+			helpers.CmdHelpers.InputFormatDesc(),
+		)
+	}
+
+	return parsedTime, nil
+}
+
+// FormatDiff returns the time elapsed from fromTime to toTime in the indicated style.
+// The iso and human styles break the difference down by calendar units, evaluated in fromTime's location.
+func FormatDiff(fromTime, toTime time.Time, diffStyle helpers.DiffStyle) string {
+	elapsed := toTime.Sub(fromTime)
+
+	switch diffStyle {
+	case helpers.DiffStyle_Secs:
+		return strconv.FormatFloat(elapsed.Seconds(), 'f', -1, 64)
+	case helpers.DiffStyle_Millis:
+		return strconv.FormatFloat(float64(elapsed)/float64(time.Millisecond), 'f', -1, 64)
+	case helpers.DiffStyle_ISO:
+		return helpers.CalendarDurationBetween(fromTime, toTime).ISOString()
+	case helpers.DiffStyle_Human:
+		return helpers.CalendarDurationBetween(fromTime, toTime).HumanString()
+	default:
+		return elapsed.String()
+	}
+}
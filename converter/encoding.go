@@ -0,0 +1,173 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package converter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hobysmith/timeconverter/helpers"
+	"gopkg.in/yaml.v3"
+	"strings"
+	"time"
+)
+
+// ConversionDocument is the structured output of a conversion, written when an output encoding is set.
+// When the conversion fails, only the input details that are known and Error are set.
+type ConversionDocument struct {
+	Input               string                     `json:"input" yaml:"input"`
+	InputFormat         string                     `json:"inputFormat,omitempty" yaml:"inputFormat,omitempty"`
+	DetectedInputFormat string                     `json:"detectedInputFormat,omitempty" yaml:"detectedInputFormat,omitempty"`
+	UnixNano            *int64                     `json:"unixNano,omitempty" yaml:"unixNano,omitempty"`
+	Results             []ConversionDocumentResult `json:"results,omitempty" yaml:"results,omitempty"`
+	Error               *ConversionDocumentError   `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ConversionDocumentResult is a single converted result, for one output format in one timezone
+type ConversionDocumentResult struct {
+	OutputFormat     string `json:"outputFormat" yaml:"outputFormat"`
+	OutputLayout     string `json:"outputLayout,omitempty" yaml:"outputLayout,omitempty"`
+	Result           string `json:"result" yaml:"result"`
+	TimeZone         string `json:"timezone" yaml:"timezone"`
+	ZoneAbbreviation string `json:"zoneAbbreviation,omitempty" yaml:"zoneAbbreviation,omitempty"`
+	OffsetSeconds    int    `json:"offsetSeconds" yaml:"offsetSeconds"`
+}
+
+// ConversionDocumentError describes a failed conversion.  ExitCodeName is the name of the ExitCode constant.
+type ConversionDocumentError struct {
+	ExitCode     int    `json:"exitCode" yaml:"exitCode"`
+	ExitCodeName string `json:"exitCodeName" yaml:"exitCodeName"`
+	Message      string `json:"message" yaml:"message"`
+}
+
+// resolveOutputEncoding determines the OutputEncoding type from CmdHelpers.OutputEncodingName.
+// An empty name is the same as text.
+func (tfd *TimeConverter) resolveOutputEncoding() error {
+	helpers.CmdHelpers.OutputEncoding = helpers.OutputEncoding_Text
+	if helpers.CmdHelpers.OutputEncodingName == "" {
+		return nil
+	}
+
+	outputEncoding, found := helpers.OutputEncodingNameToEncoding[strings.ToLower(helpers.CmdHelpers.OutputEncodingName)]
+	if !found {
+		return fmt.Errorf("Unknown output-encoding: %s", helpers.CmdHelpers.OutputEncodingName)
+	}
+
+	if outputEncoding != helpers.OutputEncoding_Text && helpers.CmdHelpers.BatchMode {
+		return errors.New("Output encoding is only supported when converting a single value")
+	}
+
+	helpers.CmdHelpers.OutputEncoding = outputEncoding
+	return nil
+}
+
+// BuildConversionDocument returns the document for a completed conversion.  zoneTimes holds the converted time for each
+// output timezone, and CmdHelpers.ConvertedResults must hold the results for each of the output formats in each zone.
+func BuildConversionDocument(inputVal string, zoneTimes []time.Time) ConversionDocument {
+	doc := newConversionDocument(inputVal)
+
+	unixNano := zoneTimes[0].UnixNano()
+	doc.UnixNano = &unixNano
+
+	resultIndex := 0
+	for _, zoneTime := range zoneTimes {
+		zoneAbbreviation, offsetSeconds := zoneTime.Zone()
+		for _, outputFormat := range helpers.CmdHelpers.OutputFormats {
+			result := ConversionDocumentResult{
+				OutputFormat:     outputFormat.Name(),
+				OutputLayout:     outputFormat.Layout,
+				Result:           helpers.CmdHelpers.ConvertedResults[resultIndex],
+				TimeZone:         zoneName(zoneTime),
+				ZoneAbbreviation: zoneAbbreviation,
+				OffsetSeconds:    offsetSeconds,
+			}
+			doc.Results = append(doc.Results, result)
+			resultIndex++
+		}
+	}
+
+	return doc
+}
+
+// BuildErrorDocument returns the document for a failed conversion, using the current ExitCode
+func BuildErrorDocument(inputVal string, err error) ConversionDocument {
+	doc := newConversionDocument(inputVal)
+	doc.Error = &ConversionDocumentError{
+		ExitCode:     helpers.ExitCode,
+		ExitCodeName: helpers.ExitCodeToName[helpers.ExitCode],
+		Message:      err.Error(),
+	}
+
+	return doc
+}
+
+// newConversionDocument returns a document with the input details that are known at this point
+func newConversionDocument(inputVal string) ConversionDocument {
+	doc := ConversionDocument{Input: inputVal}
+
+	// the name is looked up again, since the input format may not have been resolved when the conversion failed
+	if inputFormat, found := helpers.NameToTimeFormat[strings.ToUpper(helpers.CmdHelpers.InputFormatName)]; found {
+		doc.InputFormat = helpers.TimeFormatToName[inputFormat]
+	}
+
+	if helpers.CmdHelpers.InputFormat == helpers.TimeFormat_Auto &&
+		helpers.CmdHelpers.DetectedInputFormat != helpers.TimeFormat_Auto {
+		doc.DetectedInputFormat = helpers.TimeFormatToName[helpers.CmdHelpers.DetectedInputFormat]
+	}
+
+	return doc
+}
+
+// zoneName returns the name of t's location.  Locations created for fixed offsets do not have a name,
+// so the offset is used instead.
+func zoneName(t time.Time) string {
+	if name := t.Location().String(); name != "" {
+		return name
+	}
+
+	return t.Format("-07:00")
+}
+
+// EncodeConversionDocument returns the document in the indicated encoding
+func EncodeConversionDocument(doc ConversionDocument, outputEncoding helpers.OutputEncoding) (string, error) {
+	switch outputEncoding {
+	case helpers.OutputEncoding_JSON:
+		docBytes, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(docBytes) + "\n", nil
+	case helpers.OutputEncoding_YAML:
+		docBytes, err := yaml.Marshal(doc)
+		if err != nil {
+			return "", err
+		}
+		return string(docBytes), nil
+	default:
+		return "", errors.New("Output encoding is not a structured encoding")
+	}
+}
+
+// printConversionDocument writes the encoded document to the output.  The document is always written,
+// even when OutputValueOnly is set, since it is the value.
+func (tfd *TimeConverter) printConversionDocument(doc ConversionDocument) error {
+	encodedDoc, err := EncodeConversionDocument(doc, helpers.CmdHelpers.OutputEncoding)
+	if err != nil {
+		return fmt.Errorf("Unable to encode the output: %s", err)
+	}
+
+	helpers.OP.Printf(helpers.OutputMode_Force, "%s", encodedDoc)
+	return nil
+}
+
+// PrintEncodedError writes the error document for a failed conversion.  It returns false if no output encoding
+// is set, in which case the caller should report the error as usual.
+func (tfd *TimeConverter) PrintEncodedError(err error) bool {
+	if helpers.CmdHelpers.OutputEncoding == helpers.OutputEncoding_Text {
+		return false
+	}
+
+	return tfd.printConversionDocument(BuildErrorDocument(helpers.CmdHelpers.Value, err)) == nil
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package converter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hobysmith/timeconverter/helpers"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Explain parses CmdHelpers.Value and stores a report describing the time in CmdHelpers.ConvertedResult.
+// The report covers the instant in UTC, local time and the target zone, its calendar details, the daylight
+// savings state and zone transitions in the target zone, its age relative to now, and its rendering in every
+// format that does not need a layout.  The target zone is the output timezone, or the local timezone if none is set.
+// Note that the parameter fullQuiet means NOTHING should be output from this app.
+func (tfd *TimeConverter) Explain(fullQuiet bool) error {
+	if err := tfd.ResolveFormats(); err != nil {
+		return err
+	}
+
+	if len(helpers.CmdHelpers.OutputTimeZones) > 1 {
+		return errors.New("Explain supports a single output timezone")
+	}
+
+	if helpers.CmdHelpers.Value == "" {
+		helpers.ExitCode = helpers.ExitCodeErrorNoInputProvided
+		return errors.New("No input provided")
+	}
+
+	inputTime, err := tfd.ParseAndOffsetValue(helpers.CmdHelpers.Value)
+	if err != nil {
+		return err
+	}
+
+	targetTime := inputTime.In(time.Local)
+	if len(helpers.CmdHelpers.OutputTimeZones) == 1 {
+		targetTime, err = tfd.AdjustOutputTime(inputTime, helpers.CmdHelpers.OutputTimeZones[0])
+		if err != nil {
+			return err
+		}
+	}
+
+	helpers.CmdHelpers.ConvertedResult = ExplainTime(helpers.CmdHelpers.Value, targetTime, time.Now())
+
+	if fullQuiet {
+		return nil
+	}
+
+	helpers.OP.Printf(helpers.OutputMode_Force, "%s", helpers.CmdHelpers.ConvertedResult)
+	return nil
+}
+
+// ExplainTime returns the report for Explain.  targetTime should be in the target zone, and the age is
+// described relative to now.
+func ExplainTime(inputVal string, targetTime, now time.Time) string {
+	reportBuilder := new(strings.Builder)
+	reportWriter := tabwriter.NewWriter(reportBuilder, 0, 0, 1, ' ', 0)
+	writeLine := func(label, value string) {
+		fmt.Fprintf(reportWriter, "  %s\t: %s\n", label, value)
+	}
+	writeSection := func(title string) {
+		fmt.Fprintf(reportWriter, "\n%s\n", title)
+	}
+
+	fmt.Fprintf(reportWriter, "Input: %s\n", inputVal)
+	if helpers.CmdHelpers.InputFormat == helpers.TimeFormat_Auto &&
+		helpers.CmdHelpers.DetectedInputFormat != helpers.TimeFormat_Auto {
+		fmt.Fprintf(reportWriter, "Detected Input Format: %s\n", helpers.TimeFormatToName[helpers.CmdHelpers.DetectedInputFormat])
+	}
+
+	targetZoneName := zoneName(targetTime)
+
+	writeSection("Instant")
+	writeLine("UTC", targetTime.UTC().Format(time.RFC3339Nano))
+	writeLine("Local", describeZonedTime(targetTime.In(time.Local)))
+	if targetTime.Location() != time.Local {
+		writeLine(targetZoneName, describeZonedTime(targetTime))
+	}
+
+	writeSection(fmt.Sprintf("Calendar (%s)", targetZoneName))
+	writeLine("Weekday", targetTime.Weekday().String())
+	writeLine("ISO Week", helpers.FormatISOWeekDate(targetTime))
+	writeLine("Day of Year", fmt.Sprintf("%d", targetTime.YearDay()))
+	writeLine("Quarter", fmt.Sprintf("Q%d", (int(targetTime.Month())-1)/3+1))
+	writeLine("Leap Year", yesOrNo(helpers.DaysInMonth(targetTime.Year(), time.February) == 29))
+
+	zoneAbbreviation, _ := targetTime.Zone()
+	transitionStart, transitionEnd := targetTime.ZoneBounds()
+
+	writeSection(fmt.Sprintf("Timezone (%s)", targetZoneName))
+	writeLine("Zone", strings.TrimSpace(fmt.Sprintf("%s %s", zoneAbbreviation, targetTime.Format("-07:00"))))
+	writeLine("DST In Effect", yesOrNo(targetTime.IsDST()))
+	writeLine("Previous Transition", describeZoneTransition(transitionStart))
+	writeLine("Next Transition", describeZoneTransition(transitionEnd))
+
+	writeSection("Age")
+	writeLine("Relative To Now", describeAge(targetTime, now))
+
+	writeSection(fmt.Sprintf("Formats (%s)", targetZoneName))
+	for timeFormat := helpers.TimeFormat(0); int(timeFormat) < len(helpers.TimeFormatToName); timeFormat++ {
+		if !helpers.RendersWithoutLayout(timeFormat) || helpers.IsOutputOnlyTimeFormat(timeFormat) {
+			continue
+		}
+
+		formattedTime, err := helpers.NewDateTimeFormatter(targetTime).FormatDateTime(timeFormat)
+		if err != nil {
+			formattedTime = err.Error()
+		}
+		writeLine(helpers.TimeFormatToName[timeFormat], formattedTime)
+	}
+
+	_ = reportWriter.Flush()
+	return reportBuilder.String()
+}
+
+// describeZonedTime returns the time with its zone abbreviation, like "2023-09-24T10:00:00-05:00 (CDT)"
+func describeZonedTime(t time.Time) string {
+	zoneAbbreviation, _ := t.Zone()
+	if zoneAbbreviation == "" {
+		return t.Format(time.RFC3339Nano)
+	}
+
+	return fmt.Sprintf("%s (%s)", t.Format(time.RFC3339Nano), zoneAbbreviation)
+}
+
+// describeZoneTransition describes a zone change returned by ZoneBounds, like
+// "2023-11-05T01:00:00-06:00 (CDT -05:00 to CST -06:00)".  A zero time means there is no transition.
+func describeZoneTransition(transition time.Time) string {
+	if transition.IsZero() {
+		return "none"
+	}
+
+	before := transition.Add(-time.Nanosecond)
+	beforeAbbreviation, _ := before.Zone()
+	afterAbbreviation, _ := transition.Zone()
+
+	return fmt.Sprintf(
+		"%s (%s %s to %s %s)",
+		transition.Format(time.RFC3339),
+		beforeAbbreviation,
+		before.Format("-07:00"),
+		afterAbbreviation,
+		transition.Format("-07:00"),
+	)
+}
+
+// describeAge returns how long before or after now the time is, using its two largest units,
+// like "3 days, 4 hours ago" or "in 2 months, 1 day"
+func describeAge(t, now time.Time) string {
+	return helpers.DescribeRelative(t, now, func(age helpers.CalendarDuration) string {
+		return age.HumanStringUnits(2)
+	})
+}
+
+func yesOrNo(value bool) string {
+	if value {
+		return "yes"
+	}
+
+	return "no"
+}
@@ -0,0 +1,151 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package converter
+
+import (
+	"github.com/hobysmith/timeconverter/helpers"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// expressionOffsetRegex matches a single signed duration at the end of an expression, like "-90m" or "+ 1h30m".
+// A duration must include a unit, so offsets in values like "2023-09-04 11:00:00 -0500" are not mistaken for one.
+var expressionOffsetRegex = regexp.MustCompile(
+	`(?i)([+-])\s*((?:\d+(?:\.\d+)?\s*` + helpers.DurationUnitPattern + `\s*)+)$`,
+)
+
+// dayKeywordRegex matches today, yesterday and tomorrow, with an optional time of day like "09:00" or "17:30:15"
+var dayKeywordRegex = regexp.MustCompile(`^(today|yesterday|tomorrow)(?:\s+(\d{1,2}):(\d{2})(?::(\d{2}))?)?$`)
+
+// periodKeywordRegex matches the period boundary keywords, like "start-of-week" or "end-of-month"
+var periodKeywordRegex = regexp.MustCompile(`^(start|end)-of-(day|week|month|quarter|year)$`)
+
+var dayKeywordToDays = map[string]int{
+	"today":     0,
+	"yesterday": -1,
+	"tomorrow":  1,
+}
+
+// ParseInputValue transforms the input value into a time.Time value.  In addition to values in the input format,
+// this accepts relative time expressions.  An expression is a base value followed by any number of signed durations.
+//
+// The base value can be one of these keywords, or a value in the input format:
+//   - now
+//   - today, yesterday or tomorrow, optionally followed by a time of day like "09:00"
+//   - start-of-day, start-of-week, start-of-month, start-of-quarter or start-of-year
+//   - end-of-day, end-of-week, end-of-month, end-of-quarter or end-of-year
+//
+// Some examples are "now-90m", "now+2d3h", "yesterday 09:00", "end-of-month" and "2023-09-04 11:00:00 + 1h30m".
+// Keywords are evaluated in the input timezone, or in the local timezone if no input timezone is set.
+// Weeks start on CmdHelpers.WeekStart, and months are added using CmdHelpers.MonthEndMode.  When the base value is a keyword, CmdHelpers.DetectedInputFormat
+// is left as Auto.  Values in the input format are read on the input time scale's clock, but keywords are always UTC.
+func (tfd *TimeConverter) ParseInputValue(inputVal string) (time.Time, error) {
+	helpers.CmdHelpers.DetectedInputFormat = helpers.TimeFormat_Auto
+	helpers.CmdHelpers.InputLeapSecond = false
+
+	baseText, offsets := splitExpressionOffsets(inputVal)
+
+	baseTime, isKeyword, err := resolveTimeKeyword(baseText)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !isKeyword {
+		baseTime, err = tfd.parseScaledInputTime(baseText)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	for _, offset := range offsets {
+		baseTime = offset.AddTo(baseTime, helpers.CmdHelpers.MonthEndMode)
+		helpers.CmdHelpers.InputLeapSecond = false
+	}
+
+	return baseTime, nil
+}
+
+// splitExpressionOffsets peels signed durations off the end of the expression, and returns the remaining
+// base text along with the offsets in the order they appear.  If there are only offsets, the base is "now".
+func splitExpressionOffsets(inputVal string) (baseText string, offsets []helpers.CalendarDuration) {
+	baseText = strings.TrimSpace(inputVal)
+
+	for {
+		match := expressionOffsetRegex.FindStringSubmatchIndex(baseText)
+		if match == nil {
+			break
+		}
+
+		offset, err := helpers.ParseCalendarDuration(baseText[match[4]:match[5]])
+		if err != nil {
+			break
+		}
+
+		if baseText[match[2]] == '-' {
+			offset = offset.Negate()
+		}
+
+		offsets = append([]helpers.CalendarDuration{offset}, offsets...)
+		baseText = strings.TrimSpace(baseText[:match[0]])
+	}
+
+	if baseText == "" && len(offsets) > 0 {
+		baseText = "now"
+	}
+
+	return baseText, offsets
+}
+
+// resolveTimeKeyword returns the time for a keyword like "now", "yesterday 09:00" or "start-of-week".
+// isKeyword is false if the text is not a keyword, in which case it should be parsed with the input format.
+func resolveTimeKeyword(keywordText string) (keywordTime time.Time, isKeyword bool, err error) {
+	keywordText = strings.ToLower(strings.TrimSpace(keywordText))
+
+	loc := time.Local
+	if helpers.CmdHelpers.InputTimeZone != "" {
+		loc, err = helpers.CmdHelpers.InputLocation()
+		if err != nil {
+			return time.Time{}, false, err
+		}
+	}
+
+	now := time.Now().In(loc)
+
+	if keywordText == "now" {
+		return now, true, nil
+	}
+
+	if match := periodKeywordRegex.FindStringSubmatch(keywordText); match != nil {
+		unit := helpers.CalendarUnitNameToUnit[match[2]]
+		if match[1] == "start" {
+			return helpers.StartOfCalendarUnit(now, unit, helpers.CmdHelpers.WeekStart), true, nil
+		}
+		return helpers.EndOfCalendarUnit(now, unit, helpers.CmdHelpers.WeekStart), true, nil
+	}
+
+	match := dayKeywordRegex.FindStringSubmatch(keywordText)
+	if match == nil {
+		return time.Time{}, false, nil
+	}
+
+	var hour, minute, second int
+	if match[2] != "" {
+		hour, _ = strconv.Atoi(match[2])
+		minute, _ = strconv.Atoi(match[3])
+		if match[4] != "" {
+			second, _ = strconv.Atoi(match[4])
+		}
+
+		// an invalid time of day is not a keyword, so it is reported as a normal parse failure
+		if hour > 23 || minute > 59 || second > 59 {
+			return time.Time{}, false, nil
+		}
+	}
+
+	year, month, day := now.Date()
+	return time.Date(year, month, day+dayKeywordToDays[match[1]], hour, minute, second, 0, loc), true, nil
+}
@@ -0,0 +1,358 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package converter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hobysmith/timeconverter/helpers"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type jsonNodeKind int
+
+const (
+	jsonNodeKind_Scalar jsonNodeKind = iota
+	jsonNodeKind_Object
+	jsonNodeKind_Array
+)
+
+// jsonNode holds a decoded JSON value.  Objects keep their keys in a slice, rather than a map,
+// so that the original key order is preserved when the value is encoded again.
+type jsonNode struct {
+	kind jsonNodeKind
+	// object keys, aligned with children
+	keys []string
+	// object values or array elements
+	children []*jsonNode
+	// for scalars, one of string, json.Number, bool or nil
+	scalar any
+}
+
+// jsonPathSegment is a single step in a path.  A segment either selects an object key,
+// a single array index, or every element of an array.
+type jsonPathSegment struct {
+	key        string
+	index      int
+	isIndex    bool
+	allIndexes bool
+}
+
+// ConvertJSON reads a stream of JSON values from input, which can be a single document or JSON Lines,
+// converts the values at each path in CmdHelpers.JSONPaths, and writes each value to output on its own line.
+// All other structure and key order are preserved.
+//
+// String values and numeric values are both converted.  When the output format is a Unix format, the converted
+// value is written as a number.  Otherwise, it is written as a string.  Paths that do not exist in a value are skipped.
+// Values that fail to convert are handled according to CmdHelpers.BatchErrorMode, the same as batch mode.
+// ResolveFormats must be called before calling ConvertJSON.
+func (tfd *TimeConverter) ConvertJSON(input io.Reader, output io.Writer) error {
+	if err := tfd.requireSingleOutputFormat(); err != nil {
+		return err
+	}
+
+	batchErrorMode, found := helpers.BatchErrorModeNameToMode[strings.ToLower(helpers.CmdHelpers.BatchErrorModeName)]
+	if !found {
+		return fmt.Errorf("Unknown batch-errors mode: %s", helpers.CmdHelpers.BatchErrorModeName)
+	}
+
+	if len(helpers.CmdHelpers.JSONPaths) == 0 {
+		return errors.New("No paths provided. Use --path to indicate the values to convert.")
+	}
+
+	var paths [][]jsonPathSegment
+	for _, pathText := range helpers.CmdHelpers.JSONPaths {
+		path, err := parseJSONPath(pathText)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, path)
+	}
+
+	decoder := json.NewDecoder(input)
+	decoder.UseNumber()
+
+	valueNum := 0
+	for {
+		node, err := decodeJSONNode(decoder)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+			return fmt.Errorf("Failure decoding JSON value %d: %s", valueNum+1, err)
+		}
+		valueNum++
+
+		for pathIdx, path := range paths {
+			err = tfd.convertJSONPath(node, path, batchErrorMode)
+			if err != nil {
+				return fmt.Errorf("JSON value %d, path %s: %s", valueNum, helpers.CmdHelpers.JSONPaths[pathIdx], err)
+			}
+		}
+
+		encodedBuffer := new(bytes.Buffer)
+		if err = encodeJSONNode(encodedBuffer, node); err != nil {
+			return err
+		}
+
+		if helpers.CmdHelpers.JSONPretty {
+			indentedBuffer := new(bytes.Buffer)
+			if err = json.Indent(indentedBuffer, encodedBuffer.Bytes(), "", "  "); err != nil {
+				return err
+			}
+			encodedBuffer = indentedBuffer
+		}
+		encodedBuffer.WriteString("\n")
+
+		if _, err = output.Write(encodedBuffer.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseJSONPath parses paths like ".created_at", ".events[].ts", ".items[0].meta.time" or `.["odd key"]`.
+// The leading dot is optional, and a path of just "." selects the whole value.
+func parseJSONPath(pathText string) (path []jsonPathSegment, err error) {
+	remaining := strings.TrimSpace(pathText)
+	if remaining == "" {
+		return nil, errors.New("Empty JSON path")
+	}
+
+	if remaining[0] != '.' && remaining[0] != '[' {
+		remaining = "." + remaining
+	}
+
+	for len(remaining) > 0 {
+		switch remaining[0] {
+		case '.':
+			remaining = remaining[1:]
+			keyEnd := strings.IndexAny(remaining, ".[")
+			if keyEnd == -1 {
+				keyEnd = len(remaining)
+			}
+			if keyEnd > 0 {
+				path = append(path, jsonPathSegment{key: remaining[:keyEnd]})
+			}
+			remaining = remaining[keyEnd:]
+		case '[':
+			closeIdx := strings.Index(remaining, "]")
+			if closeIdx == -1 {
+				return nil, fmt.Errorf("Invalid JSON path \"%s\": missing \"]\"", pathText)
+			}
+
+			selector := strings.TrimSpace(remaining[1:closeIdx])
+			remaining = remaining[closeIdx+1:]
+			switch {
+			case selector == "":
+				path = append(path, jsonPathSegment{allIndexes: true})
+			case selector[0] == '"':
+				key, err := strconv.Unquote(selector)
+				if err != nil {
+					return nil, fmt.Errorf("Invalid JSON path \"%s\": bad quoted key %s", pathText, selector)
+				}
+				path = append(path, jsonPathSegment{key: key})
+			default:
+				index, err := strconv.Atoi(selector)
+				if err != nil || index < 0 {
+					return nil, fmt.Errorf("Invalid JSON path \"%s\": bad array index %s", pathText, selector)
+				}
+				path = append(path, jsonPathSegment{index: index, isIndex: true})
+			}
+		default:
+			return nil, fmt.Errorf("Invalid JSON path \"%s\"", pathText)
+		}
+	}
+
+	return path, nil
+}
+
+// convertJSONPath walks the node along the path, and converts every value the path selects
+func (tfd *TimeConverter) convertJSONPath(node *jsonNode, path []jsonPathSegment, batchErrorMode helpers.BatchErrorMode) error {
+	if len(path) == 0 {
+		return tfd.convertJSONScalar(node, batchErrorMode)
+	}
+
+	segment := path[0]
+	switch {
+	case segment.allIndexes:
+		if node.kind != jsonNodeKind_Array {
+			return nil
+		}
+		for _, child := range node.children {
+			if err := tfd.convertJSONPath(child, path[1:], batchErrorMode); err != nil {
+				return err
+			}
+		}
+	case segment.isIndex:
+		if node.kind != jsonNodeKind_Array || segment.index >= len(node.children) {
+			return nil
+		}
+		return tfd.convertJSONPath(node.children[segment.index], path[1:], batchErrorMode)
+	default:
+		if node.kind != jsonNodeKind_Object {
+			return nil
+		}
+		for keyIdx, key := range node.keys {
+			if key == segment.key {
+				return tfd.convertJSONPath(node.children[keyIdx], path[1:], batchErrorMode)
+			}
+		}
+	}
+
+	return nil
+}
+
+// convertJSONScalar converts a string or numeric value in place.  Other values, like objects or null, are skipped.
+func (tfd *TimeConverter) convertJSONScalar(node *jsonNode, batchErrorMode helpers.BatchErrorMode) error {
+	if node.kind != jsonNodeKind_Scalar {
+		return nil
+	}
+
+	var inputVal string
+	switch scalar := node.scalar.(type) {
+	case string:
+		inputVal = strings.TrimSpace(scalar)
+	case json.Number:
+		inputVal = scalar.String()
+	default:
+		return nil
+	}
+
+	if inputVal == "" {
+		return nil
+	}
+
+	convertedResult, err := tfd.ConvertValue(inputVal)
+	if err != nil {
+		switch batchErrorMode {
+		case helpers.BatchErrorMode_PassThrough:
+			helpers.ExitCode = helpers.ExitCodeSuccess
+			return nil
+		case helpers.BatchErrorMode_Marker:
+			helpers.ExitCode = helpers.ExitCodeSuccess
+			node.scalar = helpers.BatchErrorMarker
+			return nil
+		default:
+			if helpers.ExitCode == helpers.ExitCodeSuccess {
+				helpers.ExitCode = helpers.ExitCodeErrorDecodingInput
+			}
+			return err
+		}
+	}
+
+	if helpers.IsDecimalTimeFormat(helpers.CmdHelpers.OutputFormat) && helpers.CmdHelpers.OutputFormats[0].Template == nil {
+		node.scalar = json.Number(convertedResult)
+	} else {
+		node.scalar = convertedResult
+	}
+
+	return nil
+}
+
+// decodeJSONNode reads the next complete JSON value from the decoder
+func decodeJSONNode(decoder *json.Decoder) (*jsonNode, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, isDelim := token.(json.Delim)
+	if !isDelim {
+		return &jsonNode{kind: jsonNodeKind_Scalar, scalar: token}, nil
+	}
+
+	node := &jsonNode{kind: jsonNodeKind_Array}
+	if delim == '{' {
+		node.kind = jsonNodeKind_Object
+	}
+
+	for decoder.More() {
+		if node.kind == jsonNodeKind_Object {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			node.keys = append(node.keys, keyToken.(string))
+		}
+
+		child, err := decodeJSONNode(decoder)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		node.children = append(node.children, child)
+	}
+
+	// consume the closing delimiter
+	if _, err = decoder.Token(); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	return node, nil
+}
+
+// unexpectedEOF makes sure that an EOF in the middle of a value is not mistaken for the end of the stream
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// encodeJSONNode writes the node as compact JSON
+func encodeJSONNode(buffer *bytes.Buffer, node *jsonNode) error {
+	switch node.kind {
+	case jsonNodeKind_Object:
+		buffer.WriteByte('{')
+		for keyIdx, key := range node.keys {
+			if keyIdx > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := encodeJSONScalar(buffer, key); err != nil {
+				return err
+			}
+			buffer.WriteByte(':')
+			if err := encodeJSONNode(buffer, node.children[keyIdx]); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+	case jsonNodeKind_Array:
+		buffer.WriteByte('[')
+		for childIdx, child := range node.children {
+			if childIdx > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := encodeJSONNode(buffer, child); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+	default:
+		return encodeJSONScalar(buffer, node.scalar)
+	}
+
+	return nil
+}
+
+// encodeJSONScalar encodes a single value without escaping HTML characters, so that
+// values like "<" and "&" come back out the same as they went in.
+func encodeJSONScalar(buffer *bytes.Buffer, scalar any) error {
+	scalarBuffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(scalarBuffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(scalar); err != nil {
+		return err
+	}
+
+	buffer.Write(bytes.TrimRight(scalarBuffer.Bytes(), "\n"))
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package converter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/hobysmith/timeconverter/helpers"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// numericFormatPatterns are the patterns used to find Unix time and other numeric time values in text.  These are
+// based on the digit counts for values in recent decades, which helps avoid matching other numbers.
+var numericFormatPatterns = map[helpers.TimeFormat]string{
+	helpers.TimeFormat_Unix_Secs:         `\b\d{9,10}\b`,
+	helpers.TimeFormat_Unix_Milli:        `\b\d{12,13}\b`,
+	helpers.TimeFormat_Unix_Micro:        `\b\d{15,16}\b`,
+	helpers.TimeFormat_Unix_Nano:         `\b\d{18,19}\b`,
+	helpers.TimeFormat_FileTime:          `\b\d{17,18}\b`,
+	helpers.TimeFormat_FileTimeHex:       `\b(?:0[xX])?[0-9A-Fa-f]{16}\b`,
+	helpers.TimeFormat_DotNetTicks:       `\b\d{18}\b`,
+	helpers.TimeFormat_Excel1900:         `\b\d{5}(?:\.\d+)?\b`,
+	helpers.TimeFormat_Excel1904:         `\b\d{5}(?:\.\d+)?\b`,
+	helpers.TimeFormat_Cocoa:             `\b\d{9}(?:\.\d+)?\b`,
+	helpers.TimeFormat_WebKit:            `\b\d{17}\b`,
+	helpers.TimeFormat_NTP:               `\b\d{10}(?:\.\d+)?\b`,
+	helpers.TimeFormat_NTPHex:            `\b(?:0[xX])?[0-9A-Fa-f]{8}\.?[0-9A-Fa-f]{8}\b`,
+	helpers.TimeFormat_GPS:               `\b\d{4}:\d{1,6}(?:\.\d+)?\b`,
+	helpers.TimeFormat_UnixSecsFloat:     `\b\d{9,10}\.\d+\b`,
+	helpers.TimeFormat_UnixSecsHex:       `\b0[xX][0-9A-Fa-f]{7,8}\b`,
+	helpers.TimeFormat_UnixMilliHex:      `\b0[xX][0-9A-Fa-f]{10,11}\b`,
+	helpers.TimeFormat_UnixMicroHex:      `\b0[xX][0-9A-Fa-f]{13,14}\b`,
+	helpers.TimeFormat_UnixNanoHex:       `\b0[xX][0-9A-Fa-f]{15,16}\b`,
+	helpers.TimeFormat_JulianDay:         `\b24\d{5}(?:\.\d+)?\b`,
+	helpers.TimeFormat_ModifiedJulianDay: `\b[4-6]\d{4}(?:\.\d+)?\b`,
+	helpers.TimeFormat_RataDie:           `\b7\d{5}(?:\.\d+)?\b`,
+	helpers.TimeFormat_DecimalYear:       `\b(?:19|20)\d{2}\.\d+\b`,
+}
+
+// isoDateFormatPatterns are the patterns used to find the ISO week dates, ordinal dates and ISO8601 values in text
+var isoDateFormatPatterns = map[helpers.TimeFormat]string{
+	helpers.TimeFormat_ISOWeekDate:    `\b` + helpers.ISOWeekDatePattern + `\b`,
+	helpers.TimeFormat_ISOOrdinalDate: `\b` + helpers.ISOOrdinalDatePattern + `\b`,
+	helpers.TimeFormat_ISO8601:        `\b` + helpers.ISO8601Pattern,
+}
+
+// idFormatPatterns are the patterns used to find the identifiers that include a creation time in text
+var idFormatPatterns = map[helpers.TimeFormat]string{
+	helpers.TimeFormat_UUID:      `\b[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}\b`,
+	helpers.TimeFormat_ULID:      `\b[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}\b`,
+	helpers.TimeFormat_KSUID:     `\b[0-9A-Za-z]{27}\b`,
+	helpers.TimeFormat_ObjectID:  `\b[0-9A-Fa-f]{24}\b`,
+	helpers.TimeFormat_Snowflake: `\b\d{17,19}\b`,
+}
+
+// BuildRewriteRegex returns the regex used to find time values in text for the rewrite command.
+// If CmdHelpers.RewritePattern is set, it is used as is.  Otherwise, a pattern is built from the input format.
+// ResolveFormats must be called before calling BuildRewriteRegex.
+func (tfd *TimeConverter) BuildRewriteRegex() (*regexp.Regexp, error) {
+	if helpers.CmdHelpers.RewritePattern != "" {
+		matchRegex, err := regexp.Compile(helpers.CmdHelpers.RewritePattern)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid pattern \"%s\": %s", helpers.CmdHelpers.RewritePattern, err)
+		}
+
+		return matchRegex, nil
+	}
+
+	var layout string
+	switch inputFormat := helpers.CmdHelpers.InputFormat; {
+	case inputFormat == helpers.TimeFormat_Auto:
+		return nil, errors.New("Rewrite requires either an input format other than Auto, or a pattern")
+	case helpers.IsNumericTimeFormat(inputFormat):
+		return regexp.MustCompile(numericFormatPatterns[inputFormat]), nil
+	case helpers.IsIDTimeFormat(inputFormat):
+		return regexp.MustCompile(idFormatPatterns[inputFormat]), nil
+	case helpers.IsISODateFormat(inputFormat):
+		return regexp.MustCompile(isoDateFormatPatterns[inputFormat]), nil
+	case inputFormat == helpers.TimeFormat_CustomGO:
+		layout = helpers.CmdHelpers.InputLayout
+	case inputFormat == helpers.TimeFormat_Custom:
+		var err error
+		layout, err = helpers.NewDateTimeFormatter(time.Now()).BuildCustomLayout(helpers.CmdHelpers.InputLayout)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		layout = helpers.TimeFormatToLayout[inputFormat]
+	}
+
+	pattern, err := helpers.LayoutToRegex(layout)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to build a pattern for input format %s: %s", helpers.CmdHelpers.InputFormatDesc(), err)
+	}
+
+	return regexp.Compile(pattern)
+}
+
+// RewriteText scans the input for substrings that match the rewrite regex, converts them to the output format,
+// and writes the text to output with everything else left untouched.
+//
+// If the regex has a capture group, only the text in the first group is converted. This allows a pattern
+// to include surrounding context, like `ts=(\d+)`, without that context being replaced.
+// Matches that fail to convert are left as they are.
+func (tfd *TimeConverter) RewriteText(input io.Reader, output io.Writer) error {
+	if err := tfd.requireSingleOutputFormat(); err != nil {
+		return err
+	}
+
+	matchRegex, err := tfd.BuildRewriteRegex()
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(input)
+	for {
+		lineText, readErr := reader.ReadString('\n')
+		if len(lineText) > 0 {
+			if _, err = io.WriteString(output, tfd.rewriteLine(matchRegex, lineText)); err != nil {
+				return err
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+
+		if readErr != nil {
+			helpers.ExitCode = helpers.ExitCodeFailureReadingPipeInput
+			return fmt.Errorf("Failure reading rewrite input: %s", readErr)
+		}
+	}
+
+	return nil
+}
+
+// rewriteLine replaces all converted matches in a single line of text
+func (tfd *TimeConverter) rewriteLine(matchRegex *regexp.Regexp, lineText string) string {
+	matches := matchRegex.FindAllStringSubmatchIndex(lineText, -1)
+	if len(matches) == 0 {
+		return lineText
+	}
+
+	var lineBuilder strings.Builder
+	lastEnd := 0
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if len(match) >= 4 && match[2] >= 0 {
+			start, end = match[2], match[3]
+		}
+
+		// A match that does not convert is simply left in place, and should not fail the run
+		exitCode := helpers.ExitCode
+		convertedResult, err := tfd.ConvertValue(lineText[start:end])
+		if err != nil {
+			helpers.ExitCode = exitCode
+			continue
+		}
+
+		lineBuilder.WriteString(lineText[lastEnd:start])
+		lineBuilder.WriteString(convertedResult)
+		lastEnd = end
+	}
+	lineBuilder.WriteString(lineText[lastEnd:])
+
+	return lineBuilder.String()
+}
@@ -0,0 +1,224 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CalendarUnit identifies a calendar period, like a day or a month, for finding period boundaries.
+type CalendarUnit int
+
+const (
+	CalendarUnit_Day CalendarUnit = iota
+	CalendarUnit_Week
+	CalendarUnit_Month
+	CalendarUnit_Quarter
+	CalendarUnit_Year
+)
+
+var CalendarUnitNameToUnit = map[string]CalendarUnit{
+	"day":     CalendarUnit_Day,
+	"week":    CalendarUnit_Week,
+	"month":   CalendarUnit_Month,
+	"quarter": CalendarUnit_Quarter,
+	"year":    CalendarUnit_Year,
+}
+
+// clockUnitNameToInterval maps the units smaller than a day to their fixed length
+var clockUnitNameToInterval = map[string]time.Duration{
+	"second": time.Second,
+	"minute": time.Minute,
+	"hour":   time.Hour,
+}
+
+// WeekdayNameToWeekday maps day names, and their 3 letter abbreviations, to weekdays
+var WeekdayNameToWeekday = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"sun":       time.Sunday,
+	"monday":    time.Monday,
+	"mon":       time.Monday,
+	"tuesday":   time.Tuesday,
+	"tue":       time.Tuesday,
+	"wednesday": time.Wednesday,
+	"wed":       time.Wednesday,
+	"thursday":  time.Thursday,
+	"thu":       time.Thursday,
+	"friday":    time.Friday,
+	"fri":       time.Friday,
+	"saturday":  time.Saturday,
+	"sat":       time.Saturday,
+}
+
+// StartOfCalendarUnit returns the first instant of the period that contains t, in t's location.
+// Weeks start on weekStart.
+func StartOfCalendarUnit(t time.Time, unit CalendarUnit, weekStart time.Weekday) time.Time {
+	year, month, day := t.Date()
+	switch unit {
+	case CalendarUnit_Week:
+		daysSinceWeekStart := (int(t.Weekday()) - int(weekStart) + 7) % 7
+		return time.Date(year, month, day-daysSinceWeekStart, 0, 0, 0, 0, t.Location())
+	case CalendarUnit_Month:
+		return time.Date(year, month, 1, 0, 0, 0, 0, t.Location())
+	case CalendarUnit_Quarter:
+		return time.Date(year, ((month-1)/3)*3+1, 1, 0, 0, 0, 0, t.Location())
+	case CalendarUnit_Year:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// nextCalendarUnitStart returns the first instant of the period following the one that starts at periodStart
+func nextCalendarUnitStart(periodStart time.Time, unit CalendarUnit) time.Time {
+	year, month, day := periodStart.Date()
+	switch unit {
+	case CalendarUnit_Week:
+		return time.Date(year, month, day+7, 0, 0, 0, 0, periodStart.Location())
+	case CalendarUnit_Month:
+		return time.Date(year, month+1, 1, 0, 0, 0, 0, periodStart.Location())
+	case CalendarUnit_Quarter:
+		return time.Date(year, month+3, 1, 0, 0, 0, 0, periodStart.Location())
+	case CalendarUnit_Year:
+		return time.Date(year+1, time.January, 1, 0, 0, 0, 0, periodStart.Location())
+	default:
+		return time.Date(year, month, day+1, 0, 0, 0, 0, periodStart.Location())
+	}
+}
+
+// EndOfCalendarUnit returns the last instant of the period that contains t, which is 1ns before the next period starts.
+func EndOfCalendarUnit(t time.Time, unit CalendarUnit, weekStart time.Weekday) time.Time {
+	return nextCalendarUnitStart(StartOfCalendarUnit(t, unit, weekStart), unit).Add(-time.Nanosecond)
+}
+
+// AddMonthsClamped adds months to t, clamping the day to the last day of the resulting month.
+// For example, Jan 31 + 1 month is Feb 28 (or 29), rather than Go's normalized Mar 3.
+func AddMonthsClamped(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+
+	firstOfMonth := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	if lastDay := DaysInMonth(firstOfMonth.Year(), firstOfMonth.Month()); day > lastDay {
+		day = lastDay
+	}
+
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, hour, minute, second, t.Nanosecond(), t.Location())
+}
+
+// DaysInMonth returns the number of days in the month
+func DaysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// TimeBoundary defines the boundaries that times are truncated or rounded to.  A boundary is either
+// a calendar unit, like a week or a quarter, or a fixed interval of a day or less, like 15m.
+type TimeBoundary struct {
+	// When Interval is zero, the boundaries are the starts of Unit periods
+	Unit CalendarUnit
+	// When Interval is set, the boundaries are multiples of Interval since midnight
+	Interval time.Duration
+	// The first day of the week, for week boundaries
+	WeekStart time.Weekday
+}
+
+// ParseTimeBoundary parses a unit name, like "hour", "week" or "quarter", or a duration like "15m".
+// Durations must be no longer than a day, and they should divide evenly into a day, since the intervals restart at midnight.
+func ParseTimeBoundary(boundaryText string, weekStart time.Weekday) (TimeBoundary, error) {
+	unitName := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(boundaryText)), "s")
+	if interval, found := clockUnitNameToInterval[unitName]; found {
+		return TimeBoundary{Interval: interval}, nil
+	}
+
+	if unit, found := CalendarUnitNameToUnit[unitName]; found {
+		return TimeBoundary{Unit: unit, WeekStart: weekStart}, nil
+	}
+
+	calDuration, err := ParseCalendarDuration(boundaryText)
+	if err != nil {
+		return TimeBoundary{}, fmt.Errorf(
+			"Invalid unit \"%s\". Expected second, minute, hour, day, week, month, quarter, year or a duration like 15m",
+			boundaryText,
+		)
+	}
+
+	interval := calDuration.Clock + time.Duration(calDuration.Days)*24*time.Hour
+	if calDuration.Years != 0 || calDuration.Months != 0 || interval <= 0 || interval > 24*time.Hour {
+		return TimeBoundary{}, fmt.Errorf(
+			"Invalid duration \"%s\". Durations must be positive and no longer than a day. Use a unit like week or month for longer periods",
+			boundaryText,
+		)
+	}
+
+	return TimeBoundary{Interval: interval}, nil
+}
+
+// Truncate returns the boundary at or before t, using the calendar and wall clock of t's location
+func (tb TimeBoundary) Truncate(t time.Time) time.Time {
+	if tb.Interval == 0 {
+		return StartOfCalendarUnit(t, tb.Unit, tb.WeekStart)
+	}
+
+	sinceMidnight := wallClockSinceMidnight(t)
+	truncatedWallClock := sinceMidnight - sinceMidnight%tb.Interval
+	return wallClockTime(t, t.Add(truncatedWallClock-sinceMidnight), truncatedWallClock)
+}
+
+// Round returns the boundary nearest to t, using the calendar and wall clock of t's location.
+// Times exactly halfway between two boundaries are rounded up.
+func (tb TimeBoundary) Round(t time.Time) time.Time {
+	truncated := tb.Truncate(t)
+
+	var next time.Time
+	if tb.Interval == 0 {
+		next = nextCalendarUnitStart(truncated, tb.Unit)
+	} else {
+		nextWallClock := wallClockSinceMidnight(truncated) + tb.Interval
+		next = wallClockTime(truncated, truncated.Add(tb.Interval), nextWallClock)
+	}
+
+	if t.Sub(truncated) >= next.Sub(t) {
+		return next
+	}
+
+	return truncated
+}
+
+// wallClockSinceMidnight returns the wall clock time of day for t, as a duration
+func wallClockSinceMidnight(t time.Time) time.Duration {
+	hour, minute, second := t.Clock()
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute +
+		time.Duration(second)*time.Second + time.Duration(t.Nanosecond())
+}
+
+// wallClockTime returns the time on t's date with the indicated wall clock time of day, where a wall clock of 24h
+// or more falls on a following day.  The candidate is preferred when it has that wall clock time, so that times in
+// a repeated daylight savings hour keep their offset.  If the wall clock time does not exist, because it falls in
+// a daylight savings gap, the candidate is returned.
+func wallClockTime(t, candidate time.Time, wallClock time.Duration) time.Time {
+	year, month, day := t.Date()
+	dayStart := time.Date(year, month, day, 0, 0, 0, 0, t.Location()).AddDate(0, 0, int(wallClock/(24*time.Hour)))
+	wallClock %= 24 * time.Hour
+
+	if sameDate(candidate, dayStart) && wallClockSinceMidnight(candidate) == wallClock {
+		return candidate
+	}
+
+	expectedYear, expectedMonth, expectedDay := dayStart.Date()
+	built := time.Date(expectedYear, expectedMonth, expectedDay, 0, 0, 0, int(wallClock), t.Location())
+	if sameDate(built, dayStart) && wallClockSinceMidnight(built) == wallClock {
+		return built
+	}
+
+	return candidate
+}
+
+// sameDate returns true if both times fall on the same calendar date in their own locations
+func sameDate(t1, t2 time.Time) bool {
+	year1, month1, day1 := t1.Date()
+	year2, month2, day2 := t2.Date()
+	return year1 == year2 && month1 == month2 && day1 == day2
+}
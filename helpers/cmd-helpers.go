@@ -12,6 +12,7 @@ package helpers
 import (
 	"fmt"
 	"golang.design/x/clipboard"
+	"time"
 )
 
 // HelpersInfo is used to store the command line parameters, as well as transformed values.
@@ -26,25 +27,118 @@ type HelpersInfo struct {
 	InputFormatName string `yaml:"inputFormatName"`
 	// The input format type, determined from the input format name
 	InputFormat TimeFormat `yaml:"-"`
+	// When the input format is Auto, this is the format that was detected from the input value
+	DetectedInputFormat TimeFormat `yaml:"-"`
 	// For custom output type, the text of the custom layout
 	InputLayout string `yaml:"inputLayout"`
 	// The mapped name of the output format
 	OutputFormatName string `yaml:"outputFormatName"`
-	// The actual TimeFormat type, determined from the OutputFormatName
+	// The actual TimeFormat type, determined from the OutputFormatName.  When several output formats are
+	// provided, this is the first one.
 	OutputFormat TimeFormat `yaml:"-"`
+	// The output formats resolved from the comma separated list in OutputFormatName, with their layouts
+	OutputFormats []OutputFormatSpec `yaml:"-"`
 	// For custom output type, the text of the custom layout
 	OutputLayout string `yaml:"outputLayout"`
+	// A Go text/template used to output the converted time, like "backup-{{.DateOnly}}.tar".  Replaces the output format.
+	OutputTemplateText string `yaml:"-"`
+	// When true, the ISO8601 input format only accepts values that follow RFC 3339 exactly
+	StrictISO8601 bool `yaml:"-"`
+	// For the relative output formats, the reference time value.  If empty, the current time is used.
+	RelativeToValue string `yaml:"-"`
+	// The time parsed from RelativeToValue, or the zero time if RelativeToValue is empty
+	RelativeTo time.Time `yaml:"-"`
 	// The mapped name of the output target
 	OutputTargetName string `yaml:"outputTargetName"`
 	// The transformed OutputTarget type based on the OutputTargetName
 	OutputTarget OutputTarget `yaml:"-"`
 	// If true, no output will be emitted
 	OutputValueOnly bool `yaml:"outputValueOnly"`
+	// Determines how the root command writes its result: text, json or yaml
+	OutputEncodingName string `yaml:"-"`
+	// The OutputEncoding type, determined from OutputEncodingName
+	OutputEncoding OutputEncoding `yaml:"-"`
 	// The decoded stream
 	ConvertedResult string `yaml:"-"`
+	// The converted results for each of the OutputFormats, in the same order.  When there are several
+	// OutputTimeZones, this has the results for each of the OutputFormats for the first zone, then the next zone, and so on.
+	ConvertedResults []string `yaml:"-"`
 	// When PipeMode is true, data is read from stdin and written to stdout.
 	// Only the converted date is emitted, with possible exceptions for critical errors
 	PipeMode bool `yaml:"-"`
+	// When BatchMode is true, each line of input is converted independently and written out in order
+	BatchMode bool `yaml:"-"`
+	// Determines how batch mode handles lines that fail to convert: abort, passthrough or marker
+	BatchErrorModeName string `yaml:"batchErrorMode"`
+	// For the rewrite command, an optional regex used to find the values to convert
+	RewritePattern string `yaml:"-"`
+	// For the csv command, the comma separated list of column names or 1-based numbers to convert
+	CSVColumns string `yaml:"-"`
+	// For the csv command, the field delimiter.  Defaults to a comma.
+	CSVDelimiter string `yaml:"-"`
+	// For the csv command, indicates that the first record is a header
+	CSVHasHeader bool `yaml:"-"`
+	// For the json command, the paths of the values to convert, like ".events[].ts"
+	JSONPaths []string `yaml:"-"`
+	// For the json command, when true the output is indented instead of compact
+	JSONPretty bool `yaml:"-"`
+	// A duration to add to the input time, like "1mo" or "2d3h"
+	AddDuration string `yaml:"-"`
+	// A duration to subtract from the input time, like "90d"
+	SubtractDuration string `yaml:"-"`
+	// The offsets applied to the input time, resolved from AddDuration and SubtractDuration
+	TimeOffsets []CalendarDuration `yaml:"-"`
+	// Determines how adding months handles days that do not exist in the resulting month: clamp or overflow
+	MonthEndModeName string `yaml:"monthEndMode"`
+	// The MonthEndMode type, determined from MonthEndModeName
+	MonthEndMode MonthEndMode `yaml:"-"`
+	// A unit or duration to truncate the output time to, like "day" or "15m"
+	TruncateUnitName string `yaml:"-"`
+	// A unit or duration to round the output time to, like "hour" or "5m"
+	RoundUnitName string `yaml:"-"`
+	// The boundary resolved from TruncateUnitName, or nil if not truncating
+	TruncateBoundary *TimeBoundary `yaml:"-"`
+	// The boundary resolved from RoundUnitName, or nil if not rounding
+	RoundBoundary *TimeBoundary `yaml:"-"`
+	// The first day of the week, used for week boundaries.  Defaults to monday.
+	WeekStartName string `yaml:"weekStart"`
+	// The weekday resolved from WeekStartName
+	WeekStart time.Weekday `yaml:"-"`
+	// For the UnixSecsFloat, day count and DecimalYear output formats, the number of decimal places, from 0 to 9
+	UnixFloatPrecision int `yaml:"-"`
+	// For the Snowflake format, the layout preset: twitter, discord, instagram or custom
+	SnowflakeLayoutName string `yaml:"snowflake"`
+	// For the custom Snowflake layout, the epoch in Unix milliseconds
+	SnowflakeEpochMillis string `yaml:"snowflakeEpoch"`
+	// For the custom Snowflake layout, the number of bits below the timestamp
+	SnowflakeTimestampShift int `yaml:"snowflakeShift"`
+	// When true, SnowflakeTimestampShift was provided on the command line, rather than being the default or a saved default
+	SnowflakeTimestampShiftProvided bool `yaml:"-"`
+	// The layout resolved from SnowflakeLayoutName, SnowflakeEpochMillis and SnowflakeTimestampShift
+	SnowflakeLayout SnowflakeLayout `yaml:"-"`
+	// The time scale used for both input and output values: utc, tai or gps.  Defaults to utc.
+	TimeScaleName string `yaml:"-"`
+	// The time scale used to read input values.  If empty, TimeScaleName is used.
+	InputTimeScaleName string `yaml:"-"`
+	// The time scale used to output values.  If empty, TimeScaleName is used.
+	OutputTimeScaleName string `yaml:"-"`
+	// The TimeScale type, determined from InputTimeScaleName or TimeScaleName
+	InputTimeScale TimeScale `yaml:"-"`
+	// The TimeScale type, determined from OutputTimeScaleName or TimeScaleName
+	OutputTimeScale TimeScale `yaml:"-"`
+	// When true, the last input value was read as a leap second, like 23:59:60 UTC.  The parsed time holds 23:59:59,
+	// since a time.Time can't hold the leap second.
+	InputLeapSecond bool `yaml:"-"`
+	// For the diff command, the second value
+	DiffToValue string `yaml:"-"`
+	// For the diff command, the input format name of the second value.  If empty, the input format is used.
+	DiffToInputFormatName string `yaml:"-"`
+	// For the diff command, the input format type of the second value, determined from DiffToInputFormatName
+	DiffToInputFormat TimeFormat `yaml:"-"`
+	// For the diff command, the custom layout of the second value.  If empty, the input layout is used.
+	DiffToInputLayout string `yaml:"-"`
+	// For the diff command, the style used to output the difference: go, secs, millis, iso or human
+	DiffStyleName string `yaml:"-"`
 	// When SetDefault is true, the currently provided cmd details will be saved as a local path default
 	// for future runs.  Local path vals override global vals when both are set.
 	SetDefault bool `yaml:"-"`
@@ -54,6 +148,15 @@ type HelpersInfo struct {
 	// A timezone to use when converting the output time.  If not specified, the local time will be used for
 	// the output time.
 	OutputTimeZone string `yaml:"outputTimeZone"`
+	// The timezones resolved from the comma separated list in OutputTimeZone.  Empty when no output timezone is set.
+	OutputTimeZones []string `yaml:"-"`
+	// A timezone used when reading input values in formats that do not include a timezone, like USDateTime.
+	// If not specified, those values are read as UTC.
+	InputTimeZone string `yaml:"inputTimeZone"`
+
+	// the loaded location for InputTimeZone, cached by InputLocation()
+	inputLocation     *time.Location
+	inputLocationName string
 }
 
 // YamlConfig is used to write out default structures to local and global default files.
@@ -62,7 +165,7 @@ type YamlConfig struct {
 }
 
 var ClipboardInitialized bool
-var CmdHelpers = &HelpersInfo{}
+var CmdHelpers = &HelpersInfo{WeekStart: time.Monday, UnixFloatPrecision: DefaultUnixFloatPrecision}
 
 func InitClipboard() error {
 	if !ClipboardInitialized {
@@ -183,6 +183,34 @@ func (hi *HelpersInfo) mergeHelperInfo(newHelperInfo *HelpersInfo) {
 	if !ArgWasProvidedByUser([]string{"--output-timezone", "-z"}) {
 		CmdHelpers.OutputTimeZone = newHelperInfo.OutputTimeZone
 	}
+
+	if !ArgWasProvidedByUser([]string{"--input-timezone"}) {
+		CmdHelpers.InputTimeZone = newHelperInfo.InputTimeZone
+	}
+
+	if !ArgWasProvidedByUser([]string{"--batch-errors"}) && newHelperInfo.BatchErrorModeName != "" {
+		CmdHelpers.BatchErrorModeName = newHelperInfo.BatchErrorModeName
+	}
+
+	if !ArgWasProvidedByUser([]string{"--month-end"}) && newHelperInfo.MonthEndModeName != "" {
+		CmdHelpers.MonthEndModeName = newHelperInfo.MonthEndModeName
+	}
+
+	if !ArgWasProvidedByUser([]string{"--week-start"}) && newHelperInfo.WeekStartName != "" {
+		CmdHelpers.WeekStartName = newHelperInfo.WeekStartName
+	}
+
+	if !ArgWasProvidedByUser([]string{"--snowflake"}) && newHelperInfo.SnowflakeLayoutName != "" {
+		CmdHelpers.SnowflakeLayoutName = newHelperInfo.SnowflakeLayoutName
+	}
+
+	if !ArgWasProvidedByUser([]string{"--snowflake-epoch"}) && newHelperInfo.SnowflakeEpochMillis != "" {
+		CmdHelpers.SnowflakeEpochMillis = newHelperInfo.SnowflakeEpochMillis
+	}
+
+	if !ArgWasProvidedByUser([]string{"--snowflake-shift"}) && newHelperInfo.SnowflakeTimestampShift != 0 {
+		CmdHelpers.SnowflakeTimestampShift = newHelperInfo.SnowflakeTimestampShift
+	}
 }
 
 func ArgWasProvidedByUser(argNames []string) bool {
@@ -19,7 +19,13 @@ func NewDateTimeFormatter(dateTime time.Time) *DateTimeFormatter {
 	return &DateTimeFormatter{dateTime: dateTime}
 }
 
+// FormatDateTime formats the time using outputFormat.  For Custom and CustomGO formats, CmdHelpers.OutputLayout is used.
 func (dtf *DateTimeFormatter) FormatDateTime(outputFormat TimeFormat) (formattedDate string, err error) {
+	return dtf.FormatDateTimeWithLayout(outputFormat, CmdHelpers.OutputLayout)
+}
+
+// FormatDateTimeWithLayout formats the time using outputFormat.  For Custom and CustomGO formats, layoutText is used.
+func (dtf *DateTimeFormatter) FormatDateTimeWithLayout(outputFormat TimeFormat, layoutText string) (formattedDate string, err error) {
 	layout := "USDateTimeZ"
 	found := false
 
@@ -36,13 +42,38 @@ func (dtf *DateTimeFormatter) FormatDateTime(outputFormat TimeFormat) (formatted
 	case TimeFormat_Unix_Nano:
 		unixInt := dtf.dateTime.UnixNano()
 		return strconv.FormatInt(unixInt, 10), nil
+	case TimeFormat_UnixSecsFloat:
+		return FormatUnixSecsFloat(dtf.dateTime, CmdHelpers.UnixFloatPrecision), nil
+	case TimeFormat_FileTime, TimeFormat_FileTimeHex, TimeFormat_DotNetTicks,
+		TimeFormat_Cocoa, TimeFormat_WebKit, TimeFormat_NTP, TimeFormat_NTPHex, TimeFormat_GPS,
+		TimeFormat_UnixSecsHex, TimeFormat_UnixMilliHex, TimeFormat_UnixMicroHex, TimeFormat_UnixNanoHex:
+		return FormatEpochTime(dtf.dateTime, outputFormat)
+	case TimeFormat_UUIDv7Min, TimeFormat_UUIDv7Max, TimeFormat_ULIDMin, TimeFormat_ULIDMax,
+		TimeFormat_SnowflakeMin, TimeFormat_SnowflakeMax:
+		return FormatIDBound(dtf.dateTime, outputFormat, CmdHelpers.SnowflakeLayout)
+	case TimeFormat_JulianDay, TimeFormat_ModifiedJulianDay, TimeFormat_RataDie, TimeFormat_DecimalYear:
+		return FormatDayCountTime(dtf.dateTime, outputFormat, CmdHelpers.UnixFloatPrecision)
+	case TimeFormat_Excel1900, TimeFormat_Excel1904:
+		return FormatExcelSerial(dtf.dateTime, outputFormat == TimeFormat_Excel1904)
+	case TimeFormat_Relative, TimeFormat_RelativePrecise:
+		reference := CmdHelpers.RelativeTo
+		if reference.IsZero() {
+			reference = time.Now()
+		}
+		return FormatRelative(dtf.dateTime, reference, outputFormat == TimeFormat_RelativePrecise), nil
+	case TimeFormat_ISO8601:
+		layout = time.RFC3339Nano
+	case TimeFormat_ISOWeekDate:
+		return FormatISOWeekDate(dtf.dateTime), nil
+	case TimeFormat_ISOOrdinalDate:
+		return FormatISOOrdinalDate(dtf.dateTime), nil
 	case TimeFormat_Custom:
-		layout, err = dtf.BuildCustomLayout(CmdHelpers.OutputLayout)
+		layout, err = dtf.BuildCustomLayout(layoutText)
 		if err != nil {
 			return "", err
 		}
 	case TimeFormat_CustomGO:
-		layout = CmdHelpers.OutputLayout
+		layout = layoutText
 	default:
 		layout, found = TimeFormatToLayout[outputFormat]
 		if !found {
@@ -135,6 +166,23 @@ func (dtf *DateTimeFormatter) BuildCustomLayout(layoutText string) (convertedlay
 	return convertedlayout, nil
 }
 
+// RendersWithoutLayout returns true for formats that can output a time without a layout,
+// which is every format other than Custom, CustomGO and the input only formats
+func RendersWithoutLayout(format TimeFormat) bool {
+	return format != TimeFormat_Custom && format != TimeFormat_CustomGO && !IsInputOnlyTimeFormat(format)
+}
+
+// IsInputOnlyTimeFormat returns true for formats that can only be read, like Auto and the ID formats
+func IsInputOnlyTimeFormat(format TimeFormat) bool {
+	return format == TimeFormat_Auto || IsIDTimeFormat(format)
+}
+
+// IsOutputOnlyTimeFormat returns true for formats that can't be read back into a time, like Relative, and the
+// ID bound formats, which are read with the UUID, ULID and Snowflake formats instead
+func IsOutputOnlyTimeFormat(format TimeFormat) bool {
+	return format == TimeFormat_Relative || format == TimeFormat_RelativePrecise || IsIDBoundTimeFormat(format)
+}
+
 func IsUnixTimeFormat(format TimeFormat) bool {
 	unixTypes := []TimeFormat{
 		TimeFormat_Unix_Secs,
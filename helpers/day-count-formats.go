@@ -0,0 +1,148 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Julian Day counts days since noon UTC on 4713 BC January 1 in the proleptic Julian calendar.  Modified Julian Day
+// counts days since 1858-11-17 UTC, and Rata Die counts days since the proleptic Gregorian date 0000-12-31 UTC, so
+// 0001-01-01 is day 1.  dayCountUnixEpochs holds the day number of the Unix epoch in each of these formats.
+var dayCountUnixEpochs = map[TimeFormat]*big.Rat{
+	TimeFormat_JulianDay:         big.NewRat(4881175, 2),
+	TimeFormat_ModifiedJulianDay: big.NewRat(40587, 1),
+	TimeFormat_RataDie:           big.NewRat(719163, 1),
+}
+
+var (
+	decimalNumberRegex = regexp.MustCompile(`^[+-]?\d+(?:\.\d+)?$`)
+	nanosPerSecond     = big.NewInt(int64(time.Second))
+	nanosPerDay        = big.NewInt(secondsPerDay * int64(time.Second))
+)
+
+// IsDayCountTimeFormat returns true for the JulianDay, ModifiedJulianDay, RataDie and DecimalYear formats, whose
+// values are a count of days or years with the time as a fraction
+func IsDayCountTimeFormat(format TimeFormat) bool {
+	_, isDayCount := dayCountUnixEpochs[format]
+	return isDayCount || format == TimeFormat_DecimalYear
+}
+
+// ParseDayCountTime parses a JulianDay, ModifiedJulianDay, RataDie or DecimalYear value, like 2460189.931527.
+// The fraction is read exactly, and rounded to the nanosecond.
+func ParseDayCountTime(valueText string, format TimeFormat) (time.Time, error) {
+	formatName := TimeFormatToName[format]
+	if !decimalNumberRegex.MatchString(valueText) {
+		return time.Time{}, fmt.Errorf("Invalid %s value \"%s\". Expected a decimal number, like 2460189.931527", formatName, valueText)
+	}
+
+	value, _ := new(big.Rat).SetString(valueText)
+	if format == TimeFormat_DecimalYear {
+		return parseDecimalYear(value, valueText)
+	}
+
+	unixEpoch, found := dayCountUnixEpochs[format]
+	if !found {
+		return time.Time{}, fmt.Errorf("Unknown day count format reference: %d", int(format))
+	}
+
+	unixNanos := value.Sub(value, unixEpoch).Mul(value, new(big.Rat).SetInt(nanosPerDay))
+	unixSecs, nanos := new(big.Int).DivMod(roundRat(unixNanos), nanosPerSecond, new(big.Int))
+	if !unixSecs.IsInt64() {
+		return time.Time{}, fmt.Errorf("Invalid %s value \"%s\": value is out of range", formatName, valueText)
+	}
+
+	return time.Unix(unixSecs.Int64(), nanos.Int64()), nil
+}
+
+// FormatDayCountTime returns t in the JulianDay, ModifiedJulianDay, RataDie or DecimalYear format, with precision
+// decimal places.  Digits beyond the precision are dropped.
+func FormatDayCountTime(t time.Time, format TimeFormat, precision int) (string, error) {
+	if format == TimeFormat_DecimalYear {
+		return formatDecimalRat(decimalYearOf(t), precision), nil
+	}
+
+	unixEpoch, found := dayCountUnixEpochs[format]
+	if !found {
+		return "", fmt.Errorf("Unknown day count format reference: %d", int(format))
+	}
+
+	unixNanos := new(big.Int).Mul(big.NewInt(t.Unix()), nanosPerSecond)
+	unixNanos.Add(unixNanos, big.NewInt(int64(t.Nanosecond())))
+	days := new(big.Rat).SetFrac(unixNanos, nanosPerDay)
+
+	return formatDecimalRat(days.Add(days, unixEpoch), precision), nil
+}
+
+// parseDecimalYear parses a year with the time as a fraction of that year in UTC, like 2023.669675.
+// Leap years are longer, so the same fraction is a slightly later time of year in a leap year.
+func parseDecimalYear(value *big.Rat, valueText string) (time.Time, error) {
+	year := new(big.Int).Div(value.Num(), value.Denom())
+	if !year.IsInt64() || year.Int64() < math.MinInt32 || year.Int64() > math.MaxInt32 {
+		return time.Time{}, fmt.Errorf("Invalid DecimalYear value \"%s\": year is out of range", valueText)
+	}
+
+	yearStart, yearNanos := yearBounds(int(year.Int64()))
+	fraction := value.Sub(value, new(big.Rat).SetInt(year))
+	offsetNanos := roundRat(fraction.Mul(fraction, new(big.Rat).SetInt64(yearNanos)))
+
+	return yearStart.Add(time.Duration(offsetNanos.Int64())), nil
+}
+
+// decimalYearOf returns t's year with the time as a fraction of that year in UTC
+func decimalYearOf(t time.Time) *big.Rat {
+	t = t.UTC()
+	yearStart, yearNanos := yearBounds(t.Year())
+	elapsedNanos := t.Sub(yearStart).Nanoseconds()
+
+	decimalYear := new(big.Rat).SetFrac64(elapsedNanos, yearNanos)
+	return decimalYear.Add(decimalYear, new(big.Rat).SetInt64(int64(t.Year())))
+}
+
+// yearBounds returns the start of the year in UTC, and the number of nanoseconds in the year
+func yearBounds(year int) (yearStart time.Time, yearNanos int64) {
+	yearStart = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	nextYearStart := time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	return yearStart, nextYearStart.Sub(yearStart).Nanoseconds()
+}
+
+// roundRat returns value rounded to the nearest integer, with halves rounded away from zero
+func roundRat(value *big.Rat) *big.Int {
+	rounded, _ := new(big.Int).SetString(value.FloatString(0), 10)
+	return rounded
+}
+
+// formatDecimalRat returns value with precision decimal places, like 2460189.931527.  Digits beyond the precision
+// are dropped, so that the value never rounds up into the next day or year.
+func formatDecimalRat(value *big.Rat, precision int) string {
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+		value = new(big.Rat).Neg(value)
+	}
+
+	scaled := new(big.Int).Mul(value.Num(), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(precision)), nil))
+	scaled.Quo(scaled, value.Denom())
+	if scaled.Sign() == 0 {
+		sign = ""
+	}
+
+	digits := scaled.String()
+	if precision <= 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= precision {
+		digits = strings.Repeat("0", precision-len(digits)+1) + digits
+	}
+
+	return sign + digits[:len(digits)-precision] + "." + digits[len(digits)-precision:]
+}
@@ -0,0 +1,314 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CalendarDuration is a duration that can include calendar units.  Years, months and days are
+// applied using AddDate, so that they follow the calendar rather than being a fixed number of hours.
+// Weeks are stored as days.  Everything smaller than a day is stored in Clock.
+type CalendarDuration struct {
+	Years  int
+	Months int
+	Days   int
+	Clock  time.Duration
+}
+
+// DurationUnitPattern matches the unit names supported by ParseCalendarDuration.
+// Longer names are listed first, so that the regex alternation matches the whole unit name.
+const DurationUnitPattern = `(?:years|year|yrs|yr|y|months|month|mo|weeks|week|wks|wk|w|days|day|d|hours|hour|hrs|hr|h|` +
+	`minutes|minute|mins|min|ms|m|seconds|second|secs|sec|s|us|µs|ns)`
+
+var durationPartRegex = regexp.MustCompile(`(?i)^\s*(\d+(?:\.\d+)?)\s*(` + DurationUnitPattern + `)`)
+
+var durationUnitToClock = map[string]time.Duration{
+	"h":  time.Hour,
+	"m":  time.Minute,
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+// ParseCalendarDuration parses durations like "90m", "2d3h", "1h30m", "1y6mo" or "-1.5h".
+// Supported units are y, mo, w, d, h, m, s, ms, us and ns, as well as longer names like "months" or "mins".
+// Fractional values are only supported for hours and smaller units, since calendar units vary in length.
+func ParseCalendarDuration(durationText string) (calDuration CalendarDuration, err error) {
+	remaining := strings.TrimSpace(durationText)
+	negative := false
+	if strings.HasPrefix(remaining, "-") || strings.HasPrefix(remaining, "+") {
+		negative = remaining[0] == '-'
+		remaining = strings.TrimSpace(remaining[1:])
+	}
+
+	if remaining == "" {
+		return CalendarDuration{}, fmt.Errorf("Duration is empty")
+	}
+
+	for remaining != "" {
+		match := durationPartRegex.FindStringSubmatch(remaining)
+		if match == nil {
+			return CalendarDuration{}, fmt.Errorf("Invalid duration \"%s\". Expected values like 90m, 2d3h or 1mo", durationText)
+		}
+		remaining = strings.TrimSpace(remaining[len(match[0]):])
+
+		unit := normalizeDurationUnit(match[2])
+		clockUnit, isClockUnit := durationUnitToClock[unit]
+		if !isClockUnit {
+			if strings.Contains(match[1], ".") {
+				return CalendarDuration{}, fmt.Errorf(
+					"Invalid duration \"%s\". Fractional values are only supported for hours and smaller units", durationText)
+			}
+
+			value, err := strconv.Atoi(match[1])
+			if err != nil {
+				return CalendarDuration{}, fmt.Errorf("Invalid duration \"%s\": %s", durationText, err)
+			}
+
+			switch unit {
+			case "y":
+				calDuration.Years += value
+			case "mo":
+				calDuration.Months += value
+			case "w":
+				calDuration.Days += value * 7
+			case "d":
+				calDuration.Days += value
+			}
+			continue
+		}
+
+		value, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return CalendarDuration{}, fmt.Errorf("Invalid duration \"%s\": %s", durationText, err)
+		}
+
+		clockValue := value * float64(clockUnit)
+		if clockValue > math.MaxInt64 {
+			return CalendarDuration{}, fmt.Errorf("Invalid duration \"%s\": value is too large", durationText)
+		}
+		calDuration.Clock += time.Duration(math.Round(clockValue))
+	}
+
+	if negative {
+		calDuration = calDuration.Negate()
+	}
+
+	return calDuration, nil
+}
+
+// normalizeDurationUnit maps the long forms of unit names to their short forms
+func normalizeDurationUnit(unitText string) string {
+	unitText = strings.ToLower(unitText)
+	switch {
+	case unitText == "µs":
+		return "us"
+	case unitText == "ms" || unitText == "us" || unitText == "ns" || unitText == "mo":
+		return unitText
+	case strings.HasPrefix(unitText, "mo"):
+		return "mo"
+	case strings.HasPrefix(unitText, "mi"):
+		return "m"
+	default:
+		// y, w, d, h, m and s are the first letter of all their long forms
+		return unitText[:1]
+	}
+}
+
+// Negate returns the duration with all components negated
+func (cd CalendarDuration) Negate() CalendarDuration {
+	return CalendarDuration{
+		Years:  -cd.Years,
+		Months: -cd.Months,
+		Days:   -cd.Days,
+		Clock:  -cd.Clock,
+	}
+}
+
+// AddTo returns baseTime with the duration applied.  The years and months are applied first, using monthEndMode
+// to handle days that do not exist in the resulting month.  Then the days are applied, followed by the clock portion.
+func (cd CalendarDuration) AddTo(baseTime time.Time, monthEndMode MonthEndMode) time.Time {
+	if monthEndMode == MonthEndMode_Overflow {
+		return baseTime.AddDate(cd.Years, cd.Months, cd.Days).Add(cd.Clock)
+	}
+
+	return AddMonthsClamped(baseTime, cd.Years*12+cd.Months).AddDate(0, 0, cd.Days).Add(cd.Clock)
+}
+
+// CalendarDurationBetween breaks the time from start to end down into years, months, days and the remaining clock time.
+// The months are counted with month-end clamping, so Jan 31 to Feb 28 is 1 month.  If end is before start,
+// all the components are negative.  The end time is evaluated in the start time's location.
+func CalendarDurationBetween(start, end time.Time) CalendarDuration {
+	if end.Before(start) {
+		return CalendarDurationBetween(end, start).Negate()
+	}
+	end = end.In(start.Location())
+
+	totalMonths := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	monthsEnd := AddMonthsClamped(start, totalMonths)
+	for totalMonths > 0 && monthsEnd.After(end) {
+		totalMonths--
+		monthsEnd = AddMonthsClamped(start, totalMonths)
+	}
+
+	days := int(end.Sub(monthsEnd) / (24 * time.Hour))
+	for days > 0 && monthsEnd.AddDate(0, 0, days).After(end) {
+		days--
+	}
+	for !monthsEnd.AddDate(0, 0, days+1).After(end) {
+		days++
+	}
+
+	return CalendarDuration{
+		Years:  totalMonths / 12,
+		Months: totalMonths % 12,
+		Days:   days,
+		Clock:  end.Sub(monthsEnd.AddDate(0, 0, days)),
+	}
+}
+
+// isNegative returns true if any of the components are negative.  CalendarDurationBetween always
+// returns components with the same sign.
+func (cd CalendarDuration) isNegative() bool {
+	return cd.Years < 0 || cd.Months < 0 || cd.Days < 0 || cd.Clock < 0
+}
+
+// ISOString returns the duration in ISO 8601 duration format, like "P1Y2M3DT4H5M6.5S".
+// A negative duration is prefixed with "-".
+func (cd CalendarDuration) ISOString() string {
+	prefix := ""
+	if cd.isNegative() {
+		cd = cd.Negate()
+		prefix = "-"
+	}
+
+	var isoBuilder strings.Builder
+	isoBuilder.WriteString(prefix + "P")
+	if cd.Years > 0 {
+		isoBuilder.WriteString(fmt.Sprintf("%dY", cd.Years))
+	}
+	if cd.Months > 0 {
+		isoBuilder.WriteString(fmt.Sprintf("%dM", cd.Months))
+	}
+	if cd.Days > 0 {
+		isoBuilder.WriteString(fmt.Sprintf("%dD", cd.Days))
+	}
+
+	hours := cd.Clock / time.Hour
+	minutes := (cd.Clock % time.Hour) / time.Minute
+	seconds := cd.Clock % time.Minute
+	if cd.Clock > 0 {
+		isoBuilder.WriteString("T")
+		if hours > 0 {
+			isoBuilder.WriteString(fmt.Sprintf("%dH", hours))
+		}
+		if minutes > 0 {
+			isoBuilder.WriteString(fmt.Sprintf("%dM", minutes))
+		}
+		if seconds > 0 {
+			isoBuilder.WriteString(strconv.FormatFloat(seconds.Seconds(), 'f', -1, 64) + "S")
+		}
+	}
+
+	if isoBuilder.Len() == len(prefix)+1 {
+		// a zero duration still needs at least one component
+		return "PT0S"
+	}
+
+	return isoBuilder.String()
+}
+
+// CompactString returns the duration using short unit names, like "1y 2mo 3d 4h 5m 6s".
+// Units with a zero value are left out, and fractional seconds are truncated.  A negative duration is prefixed with "-".
+func (cd CalendarDuration) CompactString() string {
+	prefix := ""
+	if cd.isNegative() {
+		cd = cd.Negate()
+		prefix = "-"
+	}
+
+	units := []struct {
+		value int64
+		name  string
+	}{
+		{int64(cd.Years), "y"},
+		{int64(cd.Months), "mo"},
+		{int64(cd.Days), "d"},
+		{int64(cd.Clock / time.Hour), "h"},
+		{int64((cd.Clock % time.Hour) / time.Minute), "m"},
+		{int64((cd.Clock % time.Minute) / time.Second), "s"},
+	}
+
+	var parts []string
+	for _, unit := range units {
+		if unit.value != 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", unit.value, unit.name))
+		}
+	}
+
+	if len(parts) == 0 {
+		return "0s"
+	}
+
+	return prefix + strings.Join(parts, " ")
+}
+
+// HumanString returns the duration as a list of units, like "1 year, 2 months, 3 days, 4 hours, 5 minutes, 6 seconds".
+// Units with a zero value are left out, and fractional seconds are truncated.  A negative duration is prefixed with "-".
+func (cd CalendarDuration) HumanString() string {
+	return cd.HumanStringUnits(0)
+}
+
+// HumanStringUnits is the same as HumanString, but only includes the largest maxUnits units with a non-zero value,
+// like "3 days, 4 hours".  A maxUnits of 0 includes all the units.
+func (cd CalendarDuration) HumanStringUnits(maxUnits int) string {
+	prefix := ""
+	if cd.isNegative() {
+		cd = cd.Negate()
+		prefix = "-"
+	}
+
+	units := []struct {
+		value int64
+		name  string
+	}{
+		{int64(cd.Years), "year"},
+		{int64(cd.Months), "month"},
+		{int64(cd.Days), "day"},
+		{int64(cd.Clock / time.Hour), "hour"},
+		{int64((cd.Clock % time.Hour) / time.Minute), "minute"},
+		{int64((cd.Clock % time.Minute) / time.Second), "second"},
+	}
+
+	var parts []string
+	for _, unit := range units {
+		if unit.value == 0 {
+			continue
+		}
+
+		part := fmt.Sprintf("%d %s", unit.value, unit.name)
+		if unit.value != 1 {
+			part += "s"
+		}
+		parts = append(parts, part)
+
+		if len(parts) == maxUnits {
+			break
+		}
+	}
+
+	if len(parts) == 0 {
+		return "0 seconds"
+	}
+
+	return prefix + strings.Join(parts, ", ")
+}
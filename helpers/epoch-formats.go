@@ -0,0 +1,456 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tickEpoch describes a numeric time format that counts fixed size ticks from an epoch
+type tickEpoch struct {
+	name           string
+	epoch          time.Time
+	ticksPerSecond int64
+}
+
+// tickEpochs are the tick based formats.  FileTime counts 100ns intervals since 1601-01-01 UTC, .NET
+// DateTime.Ticks counts 100ns intervals since 0001-01-01 UTC, and WebKit counts microseconds since 1601-01-01 UTC.
+var tickEpochs = map[TimeFormat]tickEpoch{
+	TimeFormat_FileTime:    {"FileTime", time.Date(1601, time.January, 1, 0, 0, 0, 0, time.UTC), 10_000_000},
+	TimeFormat_FileTimeHex: {"FileTime", time.Date(1601, time.January, 1, 0, 0, 0, 0, time.UTC), 10_000_000},
+	TimeFormat_DotNetTicks: {"DotNetTicks", time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC), 10_000_000},
+	TimeFormat_WebKit:      {"WebKit", time.Date(1601, time.January, 1, 0, 0, 0, 0, time.UTC), 1_000_000},
+}
+
+// Cocoa absolute time counts seconds since 2001-01-01 UTC, and NTP counts seconds since 1900-01-01 UTC.
+// NTP timestamps have 32 bits of seconds, which roll over in 2036.  So, hex values with the high bit clear are
+// read as the next era, which covers 1968 to 2104.
+var (
+	cocoaEpoch   = time.Date(2001, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ntpEpoch     = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ntpHexRegex  = regexp.MustCompile(`^(?:0[xX])?([0-9A-Fa-f]{8})\.?([0-9A-Fa-f]{8})$`)
+	ntpEraSecs   = int64(1) << 32
+	ntpHexMinSec = ntpEpoch.Unix() + ntpEraSecs/2
+)
+
+// GPS time counts weeks and seconds of the week since 1980-01-06 UTC.  GPS time does not include leap seconds,
+// so it is ahead of UTC by the leap seconds added since 1980, which are taken from the leap second table.
+const secondsPerWeek = 7 * 24 * 60 * 60
+
+var (
+	gpsEpoch     = time.Date(1980, time.January, 6, 0, 0, 0, 0, time.UTC)
+	gpsTimeRegex = regexp.MustCompile(`^(\d+):(\d+(?:\.\d+)?)$`)
+)
+
+// The Excel 1900 date system counts days from 1899-12-30, because Lotus 1-2-3 treated 1900 as a leap year
+// and Excel kept that bug for compatibility.  Serial 60 is the nonexistent 1900-02-29, so serials
+// before it are one day off from the epoch.  The 1904 date system counts days from 1904-01-01.
+var (
+	excel1900Epoch       = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	excel1900LeapBugDate = time.Date(1900, time.March, 1, 0, 0, 0, 0, time.UTC)
+	excel1904Epoch       = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+	excelMaxDate         = time.Date(10000, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+const secondsPerDay = 24 * 60 * 60
+
+// unixHexUnits are the Unix hex formats, along with the duration of their unit
+var unixHexUnits = map[TimeFormat]time.Duration{
+	TimeFormat_UnixSecsHex:  time.Second,
+	TimeFormat_UnixMilliHex: time.Millisecond,
+	TimeFormat_UnixMicroHex: time.Microsecond,
+	TimeFormat_UnixNanoHex:  time.Nanosecond,
+}
+
+// IsNumericTimeFormat returns true for formats whose values are numbers counted from an epoch,
+// rather than date and time text.  This includes the Unix and day count formats.
+func IsNumericTimeFormat(format TimeFormat) bool {
+	if IsUnixTimeFormat(format) || IsDayCountTimeFormat(format) {
+		return true
+	}
+
+	switch format {
+	case TimeFormat_FileTime, TimeFormat_FileTimeHex, TimeFormat_DotNetTicks, TimeFormat_Excel1900, TimeFormat_Excel1904,
+		TimeFormat_Cocoa, TimeFormat_WebKit, TimeFormat_NTP, TimeFormat_NTPHex, TimeFormat_GPS, TimeFormat_UnixSecsFloat:
+		return true
+	}
+
+	_, isUnixHex := unixHexUnits[format]
+	return isUnixHex
+}
+
+// IsDecimalTimeFormat returns true for the numeric formats whose values are plain decimal numbers.
+// The hex formats and GPS, which is a week and seconds pair, are not.
+func IsDecimalTimeFormat(format TimeFormat) bool {
+	_, isUnixHex := unixHexUnits[format]
+	return IsNumericTimeFormat(format) &&
+		!isUnixHex &&
+		format != TimeFormat_FileTimeHex &&
+		format != TimeFormat_NTPHex &&
+		format != TimeFormat_GPS
+}
+
+// ParseEpochTime parses a value in one of the epoch based formats, other than the integer Unix formats and
+// the Excel formats
+func ParseEpochTime(valueText string, format TimeFormat) (time.Time, error) {
+	if unit, isUnixHex := unixHexUnits[format]; isUnixHex {
+		return parseUnixHex(valueText, format, unit)
+	}
+
+	switch format {
+	case TimeFormat_UnixSecsFloat:
+		secs, nanos, err := parseDecimalSeconds(valueText, true)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("Invalid UnixSecsFloat value \"%s\": %s", valueText, err)
+		}
+		return time.Unix(secs, nanos), nil
+	case TimeFormat_Cocoa:
+		secs, nanos, err := parseDecimalSeconds(valueText, true)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("Invalid Cocoa value \"%s\": %s", valueText, err)
+		}
+		return time.Unix(cocoaEpoch.Unix()+secs, nanos), nil
+	case TimeFormat_NTP:
+		secs, nanos, err := parseDecimalSeconds(valueText, false)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("Invalid NTP value \"%s\": %s", valueText, err)
+		}
+		return time.Unix(ntpEpoch.Unix()+secs, nanos), nil
+	case TimeFormat_NTPHex:
+		return parseNTPHex(valueText)
+	case TimeFormat_GPS:
+		return parseGPSTime(valueText)
+	default:
+		return parseTickTime(valueText, format)
+	}
+}
+
+// FormatEpochTime returns t in one of the epoch based formats, other than the integer Unix formats,
+// UnixSecsFloat and the Excel formats
+func FormatEpochTime(t time.Time, format TimeFormat) (string, error) {
+	if unit, isUnixHex := unixHexUnits[format]; isUnixHex {
+		return formatUnixHex(t, format, unit)
+	}
+
+	switch format {
+	case TimeFormat_Cocoa:
+		return formatDecimalSeconds(t.Unix()-cocoaEpoch.Unix(), t.Nanosecond()), nil
+	case TimeFormat_NTP:
+		if t.Before(ntpEpoch) {
+			return "", fmt.Errorf("NTP can't represent times before %s", ntpEpoch.Format(time.DateOnly))
+		}
+		return formatDecimalSeconds(t.Unix()-ntpEpoch.Unix(), t.Nanosecond()), nil
+	case TimeFormat_NTPHex:
+		return formatNTPHex(t)
+	case TimeFormat_GPS:
+		return formatGPSTime(t, CmdHelpers.InputLeapSecond)
+	default:
+		return formatTickTime(t, format)
+	}
+}
+
+// parseTickTime parses a FileTime, FileTimeHex, DotNetTicks or WebKit value.  FileTimeHex values may have a 0x prefix.
+func parseTickTime(valueText string, format TimeFormat) (time.Time, error) {
+	epoch, found := tickEpochs[format]
+	if !found {
+		return time.Time{}, fmt.Errorf("Unknown epoch format reference: %d", int(format))
+	}
+
+	var ticks int64
+	var err error
+	if format == TimeFormat_FileTimeHex {
+		hexText := strings.TrimPrefix(strings.TrimPrefix(valueText, "0x"), "0X")
+		var unsignedTicks uint64
+		unsignedTicks, err = strconv.ParseUint(hexText, 16, 64)
+		if err == nil && unsignedTicks > math.MaxInt64 {
+			err = fmt.Errorf("value is out of range")
+		}
+		ticks = int64(unsignedTicks)
+	} else {
+		ticks, err = strconv.ParseInt(valueText, 10, 64)
+		if err == nil && ticks < 0 {
+			err = fmt.Errorf("value can't be negative")
+		}
+	}
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid %s value \"%s\": %s", epoch.name, valueText, err)
+	}
+
+	return epoch.timeOf(ticks), nil
+}
+
+// timeOf returns the time that is ticks after the epoch.  ticks must not be negative.
+func (te tickEpoch) timeOf(ticks int64) time.Time {
+	secs := ticks / te.ticksPerSecond
+	remainingTicks := ticks % te.ticksPerSecond
+	return time.Unix(te.epoch.Unix()+secs, remainingTicks*(int64(time.Second)/te.ticksPerSecond))
+}
+
+// formatTickTime returns t as a FileTime, FileTimeHex, DotNetTicks or WebKit value.  FileTimeHex values are 16 hex
+// digits with a 0x prefix, like 0x01D9DD87398CEA00.
+func formatTickTime(t time.Time, format TimeFormat) (string, error) {
+	epoch, found := tickEpochs[format]
+	if !found {
+		return "", fmt.Errorf("Unknown epoch format reference: %d", int(format))
+	}
+
+	if t.Before(epoch.epoch) {
+		return "", fmt.Errorf("%s can't represent times before %s", epoch.name, epoch.epoch.Format(time.DateOnly))
+	}
+
+	secs := t.Unix() - epoch.epoch.Unix()
+	if secs > math.MaxInt64/epoch.ticksPerSecond-1 {
+		return "", fmt.Errorf("%s can't represent %s", epoch.name, t.Format(time.RFC3339))
+	}
+
+	ticks := secs*epoch.ticksPerSecond + int64(t.Nanosecond())/(int64(time.Second)/epoch.ticksPerSecond)
+	if format == TimeFormat_FileTimeHex {
+		return fmt.Sprintf("0x%016X", ticks), nil
+	}
+
+	return strconv.FormatInt(ticks, 10), nil
+}
+
+// FormatUnixSecsFloat returns t as Unix seconds with precision decimal places, like 1693668084.123456.
+// Digits beyond the precision are dropped.
+func FormatUnixSecsFloat(t time.Time, precision int) string {
+	secs, nanos := t.Unix(), t.Nanosecond()
+	sign := ""
+	if secs < 0 && nanos > 0 {
+		// the fraction of a negative value counts back from zero, so use the magnitude, like -1.5 rather than -2 + .5
+		sign, secs, nanos = "-", -secs-1, int(time.Second)-nanos
+	} else if secs < 0 {
+		sign, secs = "-", -secs
+	}
+
+	if precision <= 0 {
+		return sign + strconv.FormatInt(secs, 10)
+	}
+
+	return fmt.Sprintf("%s%d.%s", sign, secs, fmt.Sprintf("%09d", nanos)[:precision])
+}
+
+// parseUnixHex parses a Unix time in hex, with an optional 0x prefix, like 0x64F352F4
+func parseUnixHex(valueText string, format TimeFormat, unit time.Duration) (time.Time, error) {
+	hexText := strings.TrimPrefix(strings.TrimPrefix(valueText, "0x"), "0X")
+	unixValue, err := strconv.ParseInt(hexText, 16, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid %s value \"%s\". Expected hex digits, like 0x64F352F4", TimeFormatToName[format], valueText)
+	}
+
+	unitsPerSecond := int64(time.Second / unit)
+	return time.Unix(unixValue/unitsPerSecond, (unixValue%unitsPerSecond)*int64(unit)), nil
+}
+
+// formatUnixHex returns t as a Unix time in hex, with a 0x prefix, like 0x64F352F4
+func formatUnixHex(t time.Time, format TimeFormat, unit time.Duration) (string, error) {
+	var unixValue int64
+	switch unit {
+	case time.Second:
+		unixValue = t.Unix()
+	case time.Millisecond:
+		unixValue = t.UnixMilli()
+	case time.Microsecond:
+		unixValue = t.UnixMicro()
+	default:
+		unixValue = t.UnixNano()
+	}
+
+	if unixValue < 0 {
+		return "", fmt.Errorf("%s can't represent times before the Unix epoch", TimeFormatToName[format])
+	}
+
+	return fmt.Sprintf("0x%X", unixValue), nil
+}
+
+// parseNTPHex parses an NTP timestamp as hex, with 8 digits of seconds and 8 digits of fraction, like
+// E89D8B24.40000000.  The period and a 0x prefix are optional.
+func parseNTPHex(valueText string) (time.Time, error) {
+	match := ntpHexRegex.FindStringSubmatch(valueText)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("Invalid NTPHex value \"%s\". Expected a value like E89D8B24.40000000", valueText)
+	}
+
+	secs, _ := strconv.ParseInt(match[1], 16, 64)
+	fraction, _ := strconv.ParseInt(match[2], 16, 64)
+	if secs < ntpEraSecs/2 {
+		secs += ntpEraSecs
+	}
+
+	// the fraction is in units of 1/2^32 seconds
+	nanos := (fraction*int64(time.Second) + ntpEraSecs/2) >> 32
+	return time.Unix(ntpEpoch.Unix()+secs, nanos), nil
+}
+
+// formatNTPHex returns t as an NTP timestamp in hex, like E89D8B24.40000000
+func formatNTPHex(t time.Time) (string, error) {
+	if t.Unix() < ntpHexMinSec || t.Unix() >= ntpHexMinSec+ntpEraSecs {
+		return "", fmt.Errorf("NTPHex can't represent %s", t.Format(time.RFC3339))
+	}
+
+	secs := (t.Unix() - ntpEpoch.Unix()) % ntpEraSecs
+	fraction := (int64(t.Nanosecond())<<32 + int64(time.Second)/2) / int64(time.Second)
+	if fraction >= ntpEraSecs {
+		fraction = ntpEraSecs - 1
+	}
+
+	return fmt.Sprintf("%08X.%08X", secs, fraction), nil
+}
+
+// parseGPSTime parses a GPS week and seconds of week, like 2277:555702 or 2277:555702.5
+func parseGPSTime(valueText string) (time.Time, error) {
+	match := gpsTimeRegex.FindStringSubmatch(valueText)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("Invalid GPS value \"%s\". Expected a week and seconds of week, like 2277:555702", valueText)
+	}
+
+	week, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil || week > math.MaxInt32 {
+		return time.Time{}, fmt.Errorf("Invalid GPS value \"%s\": week is out of range", valueText)
+	}
+
+	secs, nanos, err := parseDecimalSeconds(match[2], false)
+	if err != nil || secs >= secondsPerWeek {
+		return time.Time{}, fmt.Errorf("Invalid GPS value \"%s\": seconds of week is out of range", valueText)
+	}
+
+	utcTime, _ := TimeScaleToUTC(time.Unix(gpsEpoch.Unix()+week*secondsPerWeek+secs, nanos), TimeScale_GPS)
+	return utcTime, nil
+}
+
+// formatGPSTime returns t as a GPS week and seconds of week, like 2277:555702.  When leapSecond is true, t is
+// read as the leap second 23:59:60 UTC that follows it, which is a GPS second of its own.
+func formatGPSTime(t time.Time, leapSecond bool) (string, error) {
+	gpsSecs := UTCToTimeScale(t, TimeScale_GPS, leapSecond).Unix() - gpsEpoch.Unix()
+	if gpsSecs < 0 {
+		return "", fmt.Errorf("GPS can't represent times before %s", gpsEpoch.Format(time.DateOnly))
+	}
+
+	return fmt.Sprintf("%d:%s", gpsSecs/secondsPerWeek, formatDecimalSeconds(gpsSecs%secondsPerWeek, t.Nanosecond())), nil
+}
+
+// parseDecimalSeconds parses a decimal number of seconds, like 715349484.25, without the rounding errors of
+// a float.  Fraction digits beyond nanoseconds are dropped.
+func parseDecimalSeconds(valueText string, allowNegative bool) (secs int64, nanos int64, err error) {
+	negative := strings.HasPrefix(valueText, "-")
+	if negative && !allowNegative {
+		return 0, 0, fmt.Errorf("value can't be negative")
+	}
+
+	secsText, fractionText, hasFraction := strings.Cut(strings.TrimLeft(valueText, "+-"), ".")
+	if secsText == "" || (hasFraction && fractionText == "") || strings.Trim(secsText+fractionText, "0123456789") != "" {
+		return 0, 0, fmt.Errorf("expected a number of seconds, like 715349484.25")
+	}
+
+	secs, err = strconv.ParseInt(secsText, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("value is out of range")
+	}
+
+	if len(fractionText) > 9 {
+		fractionText = fractionText[:9]
+	}
+	if fractionText != "" {
+		nanos, _ = strconv.ParseInt(fractionText+strings.Repeat("0", 9-len(fractionText)), 10, 64)
+	}
+
+	if negative {
+		return -secs, -nanos, nil
+	}
+
+	return secs, nanos, nil
+}
+
+// formatDecimalSeconds returns secs and nanos as a decimal number of seconds, like 715349484.25.
+// nanos must be between 0 and 999999999, as returned by time.Nanosecond.
+func formatDecimalSeconds(secs int64, nanos int) string {
+	sign := ""
+	if secs < 0 {
+		sign = "-"
+		secs = -secs
+		if nanos > 0 {
+			secs--
+			nanos = int(time.Second) - nanos
+		}
+	}
+
+	if nanos == 0 {
+		return sign + strconv.FormatInt(secs, 10)
+	}
+
+	return sign + strconv.FormatInt(secs, 10) + "." + strings.TrimRight(fmt.Sprintf("%09d", nanos), "0")
+}
+
+// ParseExcelSerial parses an Excel serial date, which is the number of days since the epoch of the date system,
+// with the time of day as the fraction.  Serial dates do not include a timezone, so they are read in loc.
+// The time of day is rounded to the millisecond, which is the precision Excel displays.
+func ParseExcelSerial(valueText string, loc *time.Location, system1904 bool) (time.Time, error) {
+	serial, err := strconv.ParseFloat(valueText, 64)
+	if err != nil || math.IsNaN(serial) || math.IsInf(serial, 0) {
+		return time.Time{}, fmt.Errorf("Invalid Excel serial date \"%s\"", valueText)
+	}
+
+	epoch := excel1904Epoch
+	if !system1904 {
+		epoch = excel1900Epoch
+
+		switch {
+		case serial >= 60 && serial < 61:
+			return time.Time{}, fmt.Errorf(
+				"Excel serial date \"%s\" is 1900-02-29, which does not exist. Excel keeps it for compatibility with Lotus 1-2-3",
+				valueText,
+			)
+		case serial < 60:
+			serial += 1
+		}
+	}
+
+	maxSerial := float64(excelMaxDate.Unix()-epoch.Unix()) / secondsPerDay
+	if serial < 0 || serial >= maxSerial {
+		return time.Time{}, fmt.Errorf("Excel serial date \"%s\" is out of range", valueText)
+	}
+
+	days := math.Floor(serial)
+	dayMillis := int64(math.Round((serial - days) * secondsPerDay * 1000))
+
+	return time.Date(
+		epoch.Year(),
+		epoch.Month(),
+		epoch.Day()+int(days),
+		0,
+		0,
+		int(dayMillis/1000),
+		int(dayMillis%1000)*int(time.Millisecond),
+		loc,
+	), nil
+}
+
+// FormatExcelSerial returns the wall clock time of t as an Excel serial date in the 1900 or 1904 date system
+func FormatExcelSerial(t time.Time, system1904 bool) (string, error) {
+	wallTime := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+
+	epoch, minTime := excel1904Epoch, excel1904Epoch
+	if !system1904 {
+		// serial 0 is 1900-01-00 in Excel, which is 1899-12-31
+		epoch, minTime = excel1900Epoch, excel1900Epoch.AddDate(0, 0, 1)
+	}
+
+	if wallTime.Before(minTime) || !wallTime.Before(excelMaxDate) {
+		return "", fmt.Errorf("Excel serial dates can't represent %s", t.Format(time.RFC3339))
+	}
+
+	serial := float64(wallTime.Unix()-epoch.Unix())/secondsPerDay + float64(wallTime.Nanosecond())/float64(secondsPerDay*time.Second)
+	if !system1904 && wallTime.Before(excel1900LeapBugDate) {
+		serial -= 1
+	}
+
+	return strconv.FormatFloat(serial, 'f', -1, 64), nil
+}
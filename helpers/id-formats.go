@@ -0,0 +1,250 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The alphabets used by ULID and KSUID
+const (
+	crockfordBase32Alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base62Alphabet          = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+var (
+	uuidRegex     = regexp.MustCompile(`^(?:[uU][rR][nN]:[uU][uU][iI][dD]:)?\{?([0-9A-Fa-f]{8})-?([0-9A-Fa-f]{4})-?([0-9A-Fa-f]{4})-?([0-9A-Fa-f]{4})-?([0-9A-Fa-f]{12})\}?$`)
+	objectIDRegex = regexp.MustCompile(`^[0-9A-Fa-f]{24}$`)
+
+	// UUID versions 1 and 6 count 100ns intervals since the Gregorian calendar reform
+	uuidGregorianEpoch = tickEpoch{"UUID", time.Date(1582, time.October, 15, 0, 0, 0, 0, time.UTC), 10_000_000}
+
+	// KSUID counts seconds since 2014-05-13T16:53:20Z, and is at most 160 bits
+	ksuidEpochSecs = int64(1_400_000_000)
+	ksuidMaxValue  = new(big.Int).Lsh(big.NewInt(1), 160)
+)
+
+// UUIDv7 and ULID store the milliseconds since the Unix epoch in 48 bits
+const maxIDTimestampMillis = int64(1)<<48 - 1
+
+// IsIDTimeFormat returns true for the formats that read the creation time embedded in an identifier
+func IsIDTimeFormat(format TimeFormat) bool {
+	switch format {
+	case TimeFormat_UUID, TimeFormat_ULID, TimeFormat_KSUID, TimeFormat_ObjectID, TimeFormat_Snowflake:
+		return true
+	}
+
+	return false
+}
+
+// ParseIDTime returns the creation time embedded in a UUID, ULID, KSUID or ObjectID.  Snowflake IDs need
+// a layout, so they are read with ParseSnowflakeTime.
+func ParseIDTime(idText string, format TimeFormat) (time.Time, error) {
+	switch format {
+	case TimeFormat_UUID:
+		return parseUUIDTime(idText)
+	case TimeFormat_ULID:
+		return parseULIDTime(idText)
+	case TimeFormat_KSUID:
+		return parseKSUIDTime(idText)
+	case TimeFormat_ObjectID:
+		return parseObjectIDTime(idText)
+	default:
+		return time.Time{}, fmt.Errorf("Unknown ID format reference: %d", int(format))
+	}
+}
+
+// ParseSnowflakeTime returns the creation time embedded in a Snowflake ID.  The timestamp is the milliseconds
+// since the layout's epoch, stored in the bits above the layout's timestamp shift.
+func ParseSnowflakeTime(idText string, layout SnowflakeLayout) (time.Time, error) {
+	id, err := strconv.ParseUint(idText, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid Snowflake ID \"%s\". Expected a decimal number, like 175928847299117063", idText)
+	}
+
+	return time.UnixMilli(int64(id>>layout.TimestampShift) + layout.EpochMillis), nil
+}
+
+// IsIDBoundTimeFormat returns true for the formats that output the lowest or highest ID for a time
+func IsIDBoundTimeFormat(format TimeFormat) bool {
+	switch format {
+	case TimeFormat_UUIDv7Min, TimeFormat_UUIDv7Max, TimeFormat_ULIDMin, TimeFormat_ULIDMax,
+		TimeFormat_SnowflakeMin, TimeFormat_SnowflakeMax:
+		return true
+	}
+
+	return false
+}
+
+// FormatIDBound returns the lowest or highest UUIDv7, ULID or Snowflake ID that can be created in the same
+// millisecond as t.  These are meant for range queries on tables keyed by time ordered IDs.  For rows created at
+// or after t, use id >= the Min ID.  For rows created at or before t, use id <= the Max ID.
+func FormatIDBound(t time.Time, format TimeFormat, snowflakeLayout SnowflakeLayout) (string, error) {
+	switch format {
+	case TimeFormat_UUIDv7Min, TimeFormat_UUIDv7Max:
+		return formatUUIDv7Bound(t, format == TimeFormat_UUIDv7Max)
+	case TimeFormat_ULIDMin, TimeFormat_ULIDMax:
+		return formatULIDBound(t, format == TimeFormat_ULIDMax)
+	case TimeFormat_SnowflakeMin, TimeFormat_SnowflakeMax:
+		return formatSnowflakeBound(t, snowflakeLayout, format == TimeFormat_SnowflakeMax)
+	default:
+		return "", fmt.Errorf("Unknown ID format reference: %d", int(format))
+	}
+}
+
+// formatUUIDv7Bound returns the UUIDv7 for t with the random bits all cleared, or all set when upper is true.
+// The version and variant bits are always set.
+func formatUUIDv7Bound(t time.Time, upper bool) (string, error) {
+	unixMillis := t.UnixMilli()
+	if unixMillis < 0 || unixMillis > maxIDTimestampMillis {
+		return "", fmt.Errorf("UUIDv7 can't represent %s", t.Format(time.RFC3339))
+	}
+
+	var uuidBytes [16]byte
+	binary.BigEndian.PutUint64(uuidBytes[0:8], uint64(unixMillis)<<16)
+	uuidBytes[6] = 0x70
+	uuidBytes[8] = 0x80
+	if upper {
+		uuidBytes[6] |= 0x0F
+		uuidBytes[7] = 0xFF
+		uuidBytes[8] |= 0x3F
+		for byteIdx := 9; byteIdx < len(uuidBytes); byteIdx++ {
+			uuidBytes[byteIdx] = 0xFF
+		}
+	}
+
+	uuidHex := hex.EncodeToString(uuidBytes[:])
+	return fmt.Sprintf("%s-%s-%s-%s-%s", uuidHex[0:8], uuidHex[8:12], uuidHex[12:16], uuidHex[16:20], uuidHex[20:32]), nil
+}
+
+// formatULIDBound returns the ULID for t with the random characters all 0, or all Z when upper is true
+func formatULIDBound(t time.Time, upper bool) (string, error) {
+	unixMillis := t.UnixMilli()
+	if unixMillis < 0 || unixMillis > maxIDTimestampMillis {
+		return "", fmt.Errorf("ULID can't represent %s", t.Format(time.RFC3339))
+	}
+
+	ulidBuilder := new(strings.Builder)
+	for charIdx := 9; charIdx >= 0; charIdx-- {
+		ulidBuilder.WriteByte(crockfordBase32Alphabet[(unixMillis>>(charIdx*5))&0x1F])
+	}
+
+	randomChar := "0"
+	if upper {
+		randomChar = "Z"
+	}
+	ulidBuilder.WriteString(strings.Repeat(randomChar, 16))
+
+	return ulidBuilder.String(), nil
+}
+
+// formatSnowflakeBound returns the Snowflake ID for t with the bits below the timestamp all cleared, or all set
+// when upper is true.  Those bits hold the worker and sequence numbers.
+func formatSnowflakeBound(t time.Time, layout SnowflakeLayout, upper bool) (string, error) {
+	timestamp := t.UnixMilli() - layout.EpochMillis
+	if timestamp < 0 || (layout.TimestampShift > 0 && uint64(timestamp) >= uint64(1)<<(64-layout.TimestampShift)) {
+		return "", fmt.Errorf("The snowflake layout can't represent %s", t.Format(time.RFC3339))
+	}
+
+	id := uint64(timestamp) << layout.TimestampShift
+	if upper {
+		id |= uint64(1)<<layout.TimestampShift - 1
+	}
+
+	return strconv.FormatUint(id, 10), nil
+}
+
+// parseUUIDTime returns the time in a version 1, 6 or 7 UUID.  Other versions do not include a time.
+func parseUUIDTime(idText string) (time.Time, error) {
+	match := uuidRegex.FindStringSubmatch(idText)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("Invalid UUID \"%s\". Expected a value like 017f22e2-79b0-7cc3-98c4-dc0c0c07398f", idText)
+	}
+
+	uuidBytes, _ := hex.DecodeString(strings.Join(match[1:], ""))
+	if uuidBytes[8]&0xC0 != 0x80 {
+		return time.Time{}, fmt.Errorf("UUID \"%s\" does not use the RFC 9562 variant, so its time can't be read", idText)
+	}
+
+	switch version := uuidBytes[6] >> 4; version {
+	case 1:
+		timeLow := int64(binary.BigEndian.Uint32(uuidBytes[0:4]))
+		timeMid := int64(binary.BigEndian.Uint16(uuidBytes[4:6]))
+		timeHigh := int64(binary.BigEndian.Uint16(uuidBytes[6:8]) & 0x0FFF)
+		return uuidGregorianEpoch.timeOf(timeHigh<<48 | timeMid<<32 | timeLow), nil
+	case 6:
+		timeHigh := int64(binary.BigEndian.Uint32(uuidBytes[0:4]))
+		timeMid := int64(binary.BigEndian.Uint16(uuidBytes[4:6]))
+		timeLow := int64(binary.BigEndian.Uint16(uuidBytes[6:8]) & 0x0FFF)
+		return uuidGregorianEpoch.timeOf(timeHigh<<28 | timeMid<<12 | timeLow), nil
+	case 7:
+		unixMillis := int64(binary.BigEndian.Uint64(uuidBytes[0:8]) >> 16)
+		return time.UnixMilli(unixMillis), nil
+	default:
+		return time.Time{}, fmt.Errorf("UUID \"%s\" is version %d, which does not include a time. Only versions 1, 6 and 7 do", idText, version)
+	}
+}
+
+// parseULIDTime returns the time in a ULID, which is the milliseconds since the Unix epoch in its first 10 characters
+func parseULIDTime(idText string) (time.Time, error) {
+	if len(idText) != 26 || idText[0] > '7' {
+		return time.Time{}, fmt.Errorf("Invalid ULID \"%s\". Expected 26 characters, like 01ARZ3NDEKTSV4RRFFQ69G5FAV", idText)
+	}
+
+	var unixMillis int64
+	for charIdx, char := range strings.ToUpper(idText) {
+		charValue := strings.IndexRune(crockfordBase32Alphabet, char)
+		if charValue < 0 {
+			return time.Time{}, fmt.Errorf("Invalid ULID \"%s\". \"%c\" is not a base32 character", idText, char)
+		}
+
+		if charIdx < 10 {
+			unixMillis = unixMillis<<5 | int64(charValue)
+		}
+	}
+
+	return time.UnixMilli(unixMillis), nil
+}
+
+// parseKSUIDTime returns the time in a KSUID, which is the seconds since the KSUID epoch in its first 4 bytes
+func parseKSUIDTime(idText string) (time.Time, error) {
+	if len(idText) != 27 {
+		return time.Time{}, fmt.Errorf("Invalid KSUID \"%s\". Expected 27 characters, like 0ujtsYcgvSTl8PAuAdqWYSMnLOv", idText)
+	}
+
+	ksuidValue := new(big.Int)
+	for _, char := range idText {
+		charValue := strings.IndexRune(base62Alphabet, char)
+		if charValue < 0 {
+			return time.Time{}, fmt.Errorf("Invalid KSUID \"%s\". \"%c\" is not a base62 character", idText, char)
+		}
+
+		ksuidValue.Mul(ksuidValue, big.NewInt(62))
+		ksuidValue.Add(ksuidValue, big.NewInt(int64(charValue)))
+	}
+
+	if ksuidValue.Cmp(ksuidMaxValue) >= 0 {
+		return time.Time{}, fmt.Errorf("Invalid KSUID \"%s\". The value is out of range", idText)
+	}
+
+	return time.Unix(ksuidValue.Rsh(ksuidValue, 128).Int64()+ksuidEpochSecs, 0), nil
+}
+
+// parseObjectIDTime returns the time in a MongoDB ObjectID, which is the seconds since the Unix epoch in its first 4 bytes
+func parseObjectIDTime(idText string) (time.Time, error) {
+	if !objectIDRegex.MatchString(idText) {
+		return time.Time{}, fmt.Errorf("Invalid ObjectID \"%s\". Expected 24 hex digits, like 507f1f77bcf86cd799439011", idText)
+	}
+
+	unixSecs, _ := strconv.ParseInt(idText[:8], 16, 64)
+	return time.Unix(unixSecs, 0), nil
+}
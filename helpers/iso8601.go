@@ -0,0 +1,191 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ISO8601Pattern matches ISO 8601 values with an extended calendar date in text, like "2023-09-02 10:21Z".
+// It has no capture groups, so it can be used to find values in text.
+const ISO8601Pattern = `\d{4}-\d{2}-\d{2}(?:[Tt ]\d{2}(?::\d{2}(?::\d{2})?)?(?:[.,]\d+)?(?:[Zz]|[+-]\d{2}(?::?\d{2})?)?)?`
+
+var (
+	isoCalendarDateRegex  = regexp.MustCompile(`^(\d{4})-?(\d{2})-?(\d{2})$`)
+	isoYearMonthRegex     = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+	isoYearRegex          = regexp.MustCompile(`^(\d{4})$`)
+	isoAnyWeekDateRegex   = regexp.MustCompile(`^(\d{4})-?W(\d{2})-?([1-7])$`)
+	isoAnyOrdinalRegex    = regexp.MustCompile(`^(\d{4})-?(\d{3})$`)
+	isoExtendedTimeRegex  = regexp.MustCompile(`^(\d{2})(?::(\d{2})(?::(\d{2}))?)?(?:[.,](\d+))?$`)
+	isoBasicTimeRegex     = regexp.MustCompile(`^(\d{2})(?:(\d{2})(\d{2})?)?(?:[.,](\d+))?$`)
+	isoOffsetRegex        = regexp.MustCompile(`^([+-])(\d{2})(?::?(\d{2}))?$`)
+	rfc3339StrictRegex    = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}[Tt](?:[01]\d|2[0-3]):\d{2}:\d{2}(?:\.\d+)?(?:[Zz]|[+-]\d{2}:\d{2})$`)
+	isoFractionUnitValues = []time.Duration{time.Hour, time.Minute, time.Second}
+)
+
+// ParseISO8601 parses the ISO 8601 profiles that vendors commonly produce.  This is more lenient than the RFC3339
+// format, and accepts...
+//   - calendar dates like 2023-09-02 or 20230902, week dates like 2023-W35-6, ordinal dates like 2023-245,
+//     and the reduced forms 2023-09 and 2023
+//   - "T", "t" or a space between the date and time
+//   - times with hours, minutes and seconds like 10:21:24, hours and minutes like 10:21, or just hours,
+//     in extended or basic form like 102124, and 24:00 for the end of the day
+//   - a decimal fraction of the last time component, using a period or a comma, like 10:21:24,5 or 10.5
+//   - "Z", "z", or offsets like +05, +0530 or +05:30
+//
+// Values without an offset are read in loc.  When strict is true, only values that follow RFC 3339 exactly are
+// accepted, like 2023-09-02T10:21:24Z or 2023-09-02T10:21:24.5-05:00.
+func ParseISO8601(valueText string, loc *time.Location, strict bool) (time.Time, error) {
+	if strict && !rfc3339StrictRegex.MatchString(valueText) {
+		return time.Time{}, fmt.Errorf(
+			"Invalid RFC 3339 value \"%s\". Strict mode expects values like 2023-09-02T10:21:24Z or 2023-09-02T10:21:24.5-05:00",
+			valueText,
+		)
+	}
+
+	dateText, timeText := valueText, ""
+	if timeSepIdx := strings.IndexAny(valueText, "Tt "); timeSepIdx >= 0 {
+		dateText, timeText = valueText[:timeSepIdx], valueText[timeSepIdx+1:]
+		if timeText == "" {
+			return time.Time{}, fmt.Errorf("Invalid ISO 8601 value \"%s\": the time is missing", valueText)
+		}
+	}
+
+	dateTime, err := parseISODatePart(dateText)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid ISO 8601 value \"%s\": %s", valueText, err)
+	}
+
+	var timeOfDay time.Duration
+	if timeText != "" {
+		timeText, loc, err = splitISOOffset(timeText, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("Invalid ISO 8601 value \"%s\": %s", valueText, err)
+		}
+
+		timeOfDay, err = parseISOTimePart(timeText)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("Invalid ISO 8601 value \"%s\": %s", valueText, err)
+		}
+	}
+
+	year, month, day := dateTime.Date()
+	return time.Date(year, month, day, 0, 0, 0, int(timeOfDay), loc), nil
+}
+
+// parseISODatePart parses the date portion of an ISO 8601 value, and returns midnight of that date in UTC
+func parseISODatePart(dateText string) (time.Time, error) {
+	if match := isoAnyWeekDateRegex.FindStringSubmatch(dateText); match != nil {
+		return ParseISOWeekDate(fmt.Sprintf("%s-W%s-%s", match[1], match[2], match[3]), time.UTC)
+	}
+
+	if match := isoAnyOrdinalRegex.FindStringSubmatch(dateText); match != nil {
+		return ParseISOOrdinalDate(fmt.Sprintf("%s-%s", match[1], match[2]), time.UTC)
+	}
+
+	var yearText, monthText, dayText string
+	if match := isoCalendarDateRegex.FindStringSubmatch(dateText); match != nil {
+		yearText, monthText, dayText = match[1], match[2], match[3]
+	} else if match = isoYearMonthRegex.FindStringSubmatch(dateText); match != nil {
+		yearText, monthText, dayText = match[1], match[2], "01"
+	} else if match = isoYearRegex.FindStringSubmatch(dateText); match != nil {
+		yearText, monthText, dayText = match[1], "01", "01"
+	} else {
+		return time.Time{}, fmt.Errorf("unknown date form \"%s\"", dateText)
+	}
+
+	year, _ := strconv.Atoi(yearText)
+	month, _ := strconv.Atoi(monthText)
+	day, _ := strconv.Atoi(dayText)
+
+	if month < 1 || month > 12 {
+		return time.Time{}, fmt.Errorf("month %d is out of range", month)
+	}
+
+	if day < 1 || day > DaysInMonth(year, time.Month(month)) {
+		return time.Time{}, fmt.Errorf("day %d is out of range for %04d-%02d", day, year, month)
+	}
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// splitISOOffset removes the zone designator from the end of the time text, and returns the location it indicates.
+// If there is no zone designator, loc is returned.
+func splitISOOffset(timeText string, loc *time.Location) (string, *time.Location, error) {
+	if strings.HasSuffix(timeText, "Z") || strings.HasSuffix(timeText, "z") {
+		return timeText[:len(timeText)-1], time.UTC, nil
+	}
+
+	offsetIdx := strings.LastIndexAny(timeText, "+-")
+	if offsetIdx < 0 {
+		return timeText, loc, nil
+	}
+
+	match := isoOffsetRegex.FindStringSubmatch(timeText[offsetIdx:])
+	if match == nil {
+		return "", nil, fmt.Errorf("unknown offset form \"%s\"", timeText[offsetIdx:])
+	}
+
+	hours, _ := strconv.Atoi(match[2])
+	minutes := 0
+	if match[3] != "" {
+		minutes, _ = strconv.Atoi(match[3])
+	}
+
+	if hours > 23 || minutes > 59 {
+		return "", nil, fmt.Errorf("offset \"%s\" is out of range", timeText[offsetIdx:])
+	}
+
+	offsetSeconds := hours*3600 + minutes*60
+	if match[1] == "-" {
+		offsetSeconds = -offsetSeconds
+	}
+
+	return timeText[:offsetIdx], time.FixedZone("", offsetSeconds), nil
+}
+
+// parseISOTimePart parses the time of day, without the zone designator, and returns it as the time since midnight
+func parseISOTimePart(timeText string) (time.Duration, error) {
+	match := isoExtendedTimeRegex.FindStringSubmatch(timeText)
+	if match == nil {
+		match = isoBasicTimeRegex.FindStringSubmatch(timeText)
+	}
+	if match == nil {
+		return 0, fmt.Errorf("unknown time form \"%s\"", timeText)
+	}
+
+	var timeOfDay time.Duration
+	lastUnit := time.Hour
+	limits := []int{24, 59, 59}
+	for componentIdx, componentText := range match[1:4] {
+		if componentText == "" {
+			break
+		}
+
+		value, _ := strconv.Atoi(componentText)
+		if value > limits[componentIdx] {
+			return 0, fmt.Errorf("time component %d is out of range in \"%s\"", value, timeText)
+		}
+
+		lastUnit = isoFractionUnitValues[componentIdx]
+		timeOfDay += time.Duration(value) * lastUnit
+	}
+
+	if match[4] != "" {
+		fraction, _ := strconv.ParseFloat("0."+match[4], 64)
+		timeOfDay += time.Duration(math.Round(fraction * float64(lastUnit)))
+	}
+
+	if timeOfDay > 24*time.Hour {
+		return 0, fmt.Errorf("24 is only allowed as 24:00:00 in \"%s\"", timeText)
+	}
+
+	return timeOfDay, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// ISOWeekDatePattern and ISOOrdinalDatePattern match ISO 8601 week dates, like "2023-W35-6",
+// and ordinal dates, like "2023-244".  They are not anchored and have no capture groups, so they
+// can be used to find values in text.
+const (
+	ISOWeekDatePattern    = `\d{4}-W\d{2}-[1-7]`
+	ISOOrdinalDatePattern = `\d{4}-\d{3}`
+)
+
+var isoWeekDateRegex = regexp.MustCompile(`^(\d{4})-W(\d{2})-([1-7])$`)
+var isoOrdinalDateRegex = regexp.MustCompile(`^(\d{4})-(\d{3})$`)
+
+// IsISODateFormat returns true for the ISO 8601 formats that can't be expressed as a Go layout
+func IsISODateFormat(format TimeFormat) bool {
+	return format == TimeFormat_ISOWeekDate || format == TimeFormat_ISOOrdinalDate || format == TimeFormat_ISO8601
+}
+
+// FormatISOWeekDate returns the ISO 8601 week date of t, like "2023-W35-6".  The year is the ISO week-numbering
+// year, which differs from the calendar year for some days near the start and end of a year.
+func FormatISOWeekDate(t time.Time) string {
+	isoYear, isoWeek := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d-%d", isoYear, isoWeek, isoWeekday(t))
+}
+
+// FormatISOOrdinalDate returns the ISO 8601 ordinal date of t, like "2023-244"
+func FormatISOOrdinalDate(t time.Time) string {
+	return fmt.Sprintf("%04d-%03d", t.Year(), t.YearDay())
+}
+
+// ParseISOWeekDate parses an ISO 8601 week date, like "2023-W35-6", as midnight in loc
+func ParseISOWeekDate(dateText string, loc *time.Location) (time.Time, error) {
+	match := isoWeekDateRegex.FindStringSubmatch(dateText)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("Invalid ISO week date \"%s\". Expected a value like 2023-W35-6", dateText)
+	}
+
+	isoYear, _ := strconv.Atoi(match[1])
+	week, _ := strconv.Atoi(match[2])
+	weekday, _ := strconv.Atoi(match[3])
+
+	// Dec 28 is always in the last week of its ISO year
+	_, weeksInYear := time.Date(isoYear, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	if week < 1 || week > weeksInYear {
+		return time.Time{}, fmt.Errorf("Invalid ISO week date \"%s\". %d has %d weeks", dateText, isoYear, weeksInYear)
+	}
+
+	// Jan 4 is always in week 1, so week 1 starts on the Monday on or before it
+	jan4 := time.Date(isoYear, time.January, 4, 0, 0, 0, 0, loc)
+	return time.Date(isoYear, time.January, 4-(isoWeekday(jan4)-1)+(week-1)*7+(weekday-1), 0, 0, 0, 0, loc), nil
+}
+
+// ParseISOOrdinalDate parses an ISO 8601 ordinal date, like "2023-244", as midnight in loc
+func ParseISOOrdinalDate(dateText string, loc *time.Location) (time.Time, error) {
+	match := isoOrdinalDateRegex.FindStringSubmatch(dateText)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("Invalid ISO ordinal date \"%s\". Expected a value like 2023-244", dateText)
+	}
+
+	year, _ := strconv.Atoi(match[1])
+	yearDay, _ := strconv.Atoi(match[2])
+
+	daysInYear := 365
+	if DaysInMonth(year, time.February) == 29 {
+		daysInYear = 366
+	}
+
+	if yearDay < 1 || yearDay > daysInYear {
+		return time.Time{}, fmt.Errorf("Invalid ISO ordinal date \"%s\". %d has %d days", dateText, year, daysInYear)
+	}
+
+	return time.Date(year, time.January, yearDay, 0, 0, 0, 0, loc), nil
+}
+
+// isoWeekday returns the ISO 8601 weekday number of t, with Monday as 1 and Sunday as 7
+func isoWeekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+
+	return int(t.Weekday())
+}
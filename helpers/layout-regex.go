@@ -0,0 +1,139 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	monthNamesPattern = `(?:January|February|March|April|May|June|July|August|September|October|November|December)`
+	dayNamesPattern   = `(?:Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday)`
+	zoneHHPattern     = `[+-]\d{2}`
+
+	// Go will parse fractional seconds following the seconds value, even when the layout does not include them
+	optionalFractionPattern = `(?:[.,]\d+)?`
+)
+
+// layoutTokenPatterns maps Go layout tokens to regex patterns that match the text they produce.
+// Tokens sharing a prefix are ordered longest first, so that the first match is the correct token.
+var layoutTokenPatterns = []struct {
+	token   string
+	pattern string
+}{
+	{"January", monthNamesPattern},
+	{"Jan", `[A-Z][a-z]{2}`},
+	{"Monday", dayNamesPattern},
+	{"Mon", `[A-Z][a-z]{2}`},
+	{"MST", `[A-Z]{3,5}`},
+	{"002", `\d{3}`},
+	{"01", `\d{2}`},
+	{"02", `\d{2}`},
+	{"03", `\d{2}`},
+	{"04", `\d{2}`},
+	{"05", `\d{2}` + optionalFractionPattern},
+	{"06", `\d{2}`},
+	{"15", `\d{2}`},
+	{"1", `\d{1,2}`},
+	{"2006", `\d{4}`},
+	{"2", `\d{1,2}`},
+	{"__2", `[ \d]{2}\d`},
+	{"_2006", `_\d{4}`},
+	{"_2", `[ \d]\d`},
+	{"3", `\d{1,2}`},
+	{"4", `\d{1,2}`},
+	{"5", `\d{1,2}` + optionalFractionPattern},
+	{"PM", `[AP]M`},
+	{"pm", `[ap]m`},
+	{"Z07:00:00", `(?:Z|` + zoneHHPattern + `:\d{2}:\d{2})`},
+	{"Z070000", `(?:Z|` + zoneHHPattern + `\d{4})`},
+	{"Z07:00", `(?:Z|` + zoneHHPattern + `:\d{2})`},
+	{"Z0700", `(?:Z|` + zoneHHPattern + `\d{2})`},
+	{"Z07", `(?:Z|` + zoneHHPattern + `)`},
+	{"-07:00:00", zoneHHPattern + `:\d{2}:\d{2}`},
+	{"-070000", zoneHHPattern + `\d{4}`},
+	{"-07:00", zoneHHPattern + `:\d{2}`},
+	{"-0700", zoneHHPattern + `\d{2}`},
+	{"-07", zoneHHPattern},
+}
+
+// LayoutToRegex builds a regular expression pattern that matches text produced by a Go time layout.
+// This is used to find time values embedded in free text.  The pattern is anchored on word boundaries
+// where the layout starts or ends with a letter or digit, so that values are not matched inside longer words or numbers.
+func LayoutToRegex(layout string) (string, error) {
+	if layout == "" {
+		return "", fmt.Errorf("Layout is empty")
+	}
+
+	var patternBuilder strings.Builder
+	for pos := 0; pos < len(layout); {
+		// Fractional seconds, such as ".000" or ",999", are a separator followed by a run of 0s or 9s.
+		if fractionLen, isFraction := layoutFractionLen(layout[pos:]); isFraction {
+			if layout[pos+1] == '9' {
+				patternBuilder.WriteString(fmt.Sprintf(`(?:[.,]\d{1,%d})?`, fractionLen))
+			} else {
+				patternBuilder.WriteString(fmt.Sprintf(`[.,]\d{%d}`, fractionLen))
+			}
+			pos += fractionLen + 1
+			continue
+		}
+
+		matched := false
+		for _, tokenPattern := range layoutTokenPatterns {
+			if strings.HasPrefix(layout[pos:], tokenPattern.token) {
+				patternBuilder.WriteString(tokenPattern.pattern)
+				pos += len(tokenPattern.token)
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			patternBuilder.WriteString(regexp.QuoteMeta(layout[pos : pos+1]))
+			pos++
+		}
+	}
+
+	pattern := patternBuilder.String()
+	if isWordChar(rune(layout[0])) {
+		pattern = `\b` + pattern
+	}
+	if isWordChar(rune(layout[len(layout)-1])) {
+		pattern += `\b`
+	}
+
+	return pattern, nil
+}
+
+// layoutFractionLen checks if the layout text starts with a fractional seconds spec, like ".000" or ",999".
+// If so, it returns the number of digits in the spec.
+func layoutFractionLen(layoutText string) (fractionLen int, isFraction bool) {
+	if len(layoutText) < 2 || (layoutText[0] != '.' && layoutText[0] != ',') {
+		return 0, false
+	}
+
+	if layoutText[1] != '0' && layoutText[1] != '9' {
+		return 0, false
+	}
+
+	digitPos := 1
+	for digitPos < len(layoutText) && layoutText[digitPos] == layoutText[1] {
+		digitPos++
+	}
+
+	// Go only treats this as fractional seconds if the run of 0s or 9s is not followed by another digit
+	if digitPos < len(layoutText) && unicode.IsDigit(rune(layoutText[digitPos])) {
+		return 0, false
+	}
+
+	return digitPos - 1, true
+}
+
+func isWordChar(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsDigit(char) || char == '_'
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"time"
+)
+
+// LeapSecondTableVersion identifies the built-in leap second table by the date of the last leap second it includes.
+// When the IERS announces a new leap second, add it to leapSeconds and update this version.
+const LeapSecondTableVersion = "2017-01-01"
+
+// taiOffsetBefore1972 is the TAI - UTC offset used before 1972.  UTC did not use leap seconds until then, so
+// conversions of earlier times are approximate.
+const taiOffsetBefore1972 = 10
+
+// gpsTAIOffset is the number of seconds GPS time is behind TAI.  GPS time matched UTC at the GPS epoch in 1980,
+// and has not included leap seconds since.
+const gpsTAIOffset = 19
+
+// LeapSecond is an entry in the leap second table.  From UTCStart onward, TAI is ahead of UTC by TAIOffset seconds.
+// Each entry after the first follows a leap second, inserted as 23:59:60 UTC on the day before UTCStart.
+type LeapSecond struct {
+	UTCStart  time.Time
+	TAIOffset int64
+}
+
+// leapSeconds is the built-in leap second table, from the IERS leap-seconds.list, in order
+var leapSeconds = []LeapSecond{
+	{time.Date(1972, time.January, 1, 0, 0, 0, 0, time.UTC), 10},
+	{time.Date(1972, time.July, 1, 0, 0, 0, 0, time.UTC), 11},
+	{time.Date(1973, time.January, 1, 0, 0, 0, 0, time.UTC), 12},
+	{time.Date(1974, time.January, 1, 0, 0, 0, 0, time.UTC), 13},
+	{time.Date(1975, time.January, 1, 0, 0, 0, 0, time.UTC), 14},
+	{time.Date(1976, time.January, 1, 0, 0, 0, 0, time.UTC), 15},
+	{time.Date(1977, time.January, 1, 0, 0, 0, 0, time.UTC), 16},
+	{time.Date(1978, time.January, 1, 0, 0, 0, 0, time.UTC), 17},
+	{time.Date(1979, time.January, 1, 0, 0, 0, 0, time.UTC), 18},
+	{time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC), 19},
+	{time.Date(1981, time.July, 1, 0, 0, 0, 0, time.UTC), 20},
+	{time.Date(1982, time.July, 1, 0, 0, 0, 0, time.UTC), 21},
+	{time.Date(1983, time.July, 1, 0, 0, 0, 0, time.UTC), 22},
+	{time.Date(1985, time.July, 1, 0, 0, 0, 0, time.UTC), 23},
+	{time.Date(1988, time.January, 1, 0, 0, 0, 0, time.UTC), 24},
+	{time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC), 25},
+	{time.Date(1991, time.January, 1, 0, 0, 0, 0, time.UTC), 26},
+	{time.Date(1992, time.July, 1, 0, 0, 0, 0, time.UTC), 27},
+	{time.Date(1993, time.July, 1, 0, 0, 0, 0, time.UTC), 28},
+	{time.Date(1994, time.July, 1, 0, 0, 0, 0, time.UTC), 29},
+	{time.Date(1996, time.January, 1, 0, 0, 0, 0, time.UTC), 30},
+	{time.Date(1997, time.July, 1, 0, 0, 0, 0, time.UTC), 31},
+	{time.Date(1999, time.January, 1, 0, 0, 0, 0, time.UTC), 32},
+	{time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC), 33},
+	{time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC), 34},
+	{time.Date(2012, time.July, 1, 0, 0, 0, 0, time.UTC), 35},
+	{time.Date(2015, time.July, 1, 0, 0, 0, 0, time.UTC), 36},
+	{time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC), 37},
+}
+
+// LeapSeconds returns a copy of the built-in leap second table, in order
+func LeapSeconds() []LeapSecond {
+	return append([]LeapSecond(nil), leapSeconds...)
+}
+
+// TAIOffsetAt returns the number of seconds TAI is ahead of UTC at the UTC time t
+func TAIOffsetAt(t time.Time) int64 {
+	for entryIdx := len(leapSeconds) - 1; entryIdx >= 0; entryIdx-- {
+		if !t.Before(leapSeconds[entryIdx].UTCStart) {
+			return leapSeconds[entryIdx].TAIOffset
+		}
+	}
+
+	return taiOffsetBefore1972
+}
+
+// IsLeapSecondAt returns true if a leap second was inserted right after the UTC second that t falls in.
+// That is, when t is in 23:59:59 UTC on a day that ended with 23:59:60.
+func IsLeapSecondAt(t time.Time) bool {
+	nextSecond := t.UTC().Truncate(time.Second).Add(time.Second)
+	for entryIdx := 1; entryIdx < len(leapSeconds); entryIdx++ {
+		if nextSecond.Equal(leapSeconds[entryIdx].UTCStart) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UTCToTimeScale returns the reading of the scale's clock at the UTC time t, as a time.Time in t's location.
+// When leapSecond is true, t is read as the leap second 23:59:60 that follows it, rather than as 23:59:59.
+func UTCToTimeScale(t time.Time, scale TimeScale, leapSecond bool) time.Time {
+	if scale == TimeScale_UTC {
+		return t
+	}
+
+	offset := TAIOffsetAt(t)
+	if leapSecond {
+		offset++
+	}
+
+	if scale == TimeScale_GPS {
+		offset -= gpsTAIOffset
+	}
+
+	return t.Add(time.Duration(offset) * time.Second)
+}
+
+// TimeScaleToUTC returns the UTC time for a reading of the scale's clock.  When the reading falls in a leap second,
+// the result is 23:59:59 UTC and leapSecond is true, since a time.Time can't hold 23:59:60.
+func TimeScaleToUTC(t time.Time, scale TimeScale) (utcTime time.Time, leapSecond bool) {
+	if scale == TimeScale_UTC {
+		return t, false
+	}
+
+	taiTime := t
+	if scale == TimeScale_GPS {
+		taiTime = t.Add(gpsTAIOffset * time.Second)
+	}
+
+	for entryIdx := len(leapSeconds) - 1; entryIdx >= 0; entryIdx-- {
+		entry := leapSeconds[entryIdx]
+		utcTime = taiTime.Add(-time.Duration(entry.TAIOffset) * time.Second)
+		if !utcTime.Before(entry.UTCStart) {
+			return utcTime, false
+		}
+
+		// The TAI second just before an entry's start, using the entry's offset, is the leap second
+		if entryIdx > 0 && !utcTime.Before(entry.UTCStart.Add(-time.Second)) {
+			return utcTime, true
+		}
+	}
+
+	return taiTime.Add(-taiOffsetBefore1972 * time.Second), false
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// OutputFormatSpec is a single output format, along with its layout when the format is Custom or CustomGO.
+// When Template is set, the time is output with the template instead, and Layout holds the template text.
+type OutputFormatSpec struct {
+	Format   TimeFormat
+	Layout   string
+	Template *template.Template
+}
+
+// Name returns the format name, or "Template" for output templates
+func (ofs OutputFormatSpec) Name() string {
+	if ofs.Template != nil {
+		return "Template"
+	}
+
+	return TimeFormatToName[ofs.Format]
+}
+
+// Desc returns the format name, including the layout for custom formats.  This is used to label results.
+func (ofs OutputFormatSpec) Desc() string {
+	if ofs.Template != nil {
+		return fmt.Sprintf(`Template["%s"]`, ofs.Layout)
+	}
+
+	switch ofs.Format {
+	case TimeFormat_CustomGO:
+		return fmt.Sprintf(`CustomGo["%s"]`, ofs.Layout)
+	case TimeFormat_Custom:
+		return fmt.Sprintf(`Custom["%s"]`, ofs.Layout)
+	default:
+		return TimeFormatToName[ofs.Format]
+	}
+}
+
+// ParseOutputFormatList parses a comma separated list of output format names, like "UnixMilli,RFC3339".
+// Custom and CustomGO entries can include their own layout, like "CustomGO=2006-01-02".  Custom entries
+// without a layout use defaultLayout.
+//
+// Since layouts may contain commas, like "CustomGO=Jan 2, 2006", any segment that follows a layout entry
+// and does not start with a format name is treated as part of that layout.
+func ParseOutputFormatList(listText, defaultLayout string) (outputFormats []OutputFormatSpec, err error) {
+	var entries []string
+	for _, segment := range strings.Split(listText, ",") {
+		segmentName, _, _ := strings.Cut(segment, "=")
+		_, isFormatName := NameToTimeFormat[strings.ToUpper(strings.TrimSpace(segmentName))]
+
+		if !isFormatName && len(entries) > 0 && strings.Contains(entries[len(entries)-1], "=") {
+			entries[len(entries)-1] += "," + segment
+			continue
+		}
+
+		entries = append(entries, segment)
+	}
+
+	for _, entry := range entries {
+		formatName, layout, hasLayout := strings.Cut(entry, "=")
+		formatName = strings.TrimSpace(formatName)
+		if formatName == "" {
+			continue
+		}
+
+		outputFormat, found := NameToTimeFormat[strings.ToUpper(formatName)]
+		if !found {
+			return nil, fmt.Errorf("Unknown output-format: %s", formatName)
+		}
+
+		if IsInputOnlyTimeFormat(outputFormat) {
+			return nil, fmt.Errorf("%s is only supported as an input format", TimeFormatToName[outputFormat])
+		}
+
+		isCustom := outputFormat == TimeFormat_Custom || outputFormat == TimeFormat_CustomGO
+		if hasLayout && !isCustom {
+			return nil, fmt.Errorf("Output format %s does not use a layout. Only Custom and CustomGO formats accept a layout.", formatName)
+		}
+
+		if isCustom && !hasLayout {
+			layout = defaultLayout
+		}
+
+		outputFormats = append(outputFormats, OutputFormatSpec{Format: outputFormat, Layout: layout})
+	}
+
+	if len(outputFormats) == 0 {
+		return nil, fmt.Errorf("Unknown output-format: %s", listText)
+	}
+
+	return outputFormats, nil
+}
@@ -139,3 +139,10 @@ func (op *OutputPrinter) Println(outputMode OutputMode, a ...any) {
 func (op *OutputPrinter) Printf(outputMode OutputMode, format string, a ...any) {
 	op.sendOutputText(outputMode, fmt.Sprintf(format, a...))
 }
+
+// Write allows the output printer to be used as an io.Writer, such as for streaming batch output.
+// All writes are sent using OutputMode_Force.
+func (op *OutputPrinter) Write(p []byte) (n int, err error) {
+	op.sendOutputText(OutputMode_Force, string(p))
+	return len(p), nil
+}
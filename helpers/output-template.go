@@ -0,0 +1,151 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// TemplateFieldToDescription describes the template view fields, other than the named formats.  Used by the show command.
+var TemplateFieldToDescription = []EntityDescription{
+	{"Time", "The time.Time value, for calling methods like {{.Time.Format \"Jan 2006\"}}"},
+	{"Year", "The year, like 2023"},
+	{"Month", "The month number, 1 to 12"},
+	{"MonthName", "The month name, like September"},
+	{"MonthShort", "The abbreviated month name, like Sep"},
+	{"Day", "The day of the month, 1 to 31"},
+	{"Hour", "The hour, 0 to 23"},
+	{"Hour12", "The hour on a 12 hour clock, 1 to 12"},
+	{"AMPM", "AM or PM"},
+	{"Minute", "The minute, 0 to 59"},
+	{"Second", "The second, 0 to 59"},
+	{"Millisecond", "The milliseconds within the second"},
+	{"Microsecond", "The microseconds within the second"},
+	{"Nanosecond", "The nanoseconds within the second"},
+	{"YearDay", "The day of the year, 1 to 366"},
+	{"Weekday", "The weekday name, like Monday"},
+	{"WeekdayShort", "The abbreviated weekday name, like Mon"},
+	{"WeekdayNumber", "The weekday number, with Sunday as 0"},
+	{"ISOWeekday", "The ISO 8601 weekday number, with Monday as 1 and Sunday as 7"},
+	{"ISOYear", "The ISO 8601 week-numbering year"},
+	{"ISOWeek", "The ISO 8601 week number, 1 to 53"},
+	{"Quarter", "The quarter, 1 to 4"},
+	{"IsLeapYear", "true if the year is a leap year"},
+	{"UnixSecs", "Seconds since the Unix epoch"},
+	{"UnixMilli", "Milliseconds since the Unix epoch"},
+	{"UnixMicro", "Microseconds since the Unix epoch"},
+	{"UnixNano", "Nanoseconds since the Unix epoch"},
+	{"Zone", "The zone abbreviation, like EDT"},
+	{"ZoneName", "The zone's location name, like America/New_York, or its offset if the zone has no name"},
+	{"Offset", "The offset from UTC, like -04:00"},
+	{"OffsetSeconds", "The offset from UTC in seconds"},
+	{"IsDST", "true if daylight savings time is in effect"},
+}
+
+// ParseOutputTemplate parses an output template, like "backup-{{.DateOnly}}-{{.UnixSecs}}.tar".
+// The template is executed against a sample time, so that references to values that do not exist
+// are reported up front, rather than when the first value is converted.
+func ParseOutputTemplate(templateText string) (*template.Template, error) {
+	outputTemplate, err := template.New("output-template").Option("missingkey=error").Parse(templateText)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid output template: %s", err)
+	}
+
+	if _, err = NewDateTimeFormatter(time.Now()).FormatTemplate(outputTemplate); err != nil {
+		return nil, err
+	}
+
+	return outputTemplate, nil
+}
+
+// FormatTemplate executes the template against the TemplateView of the time
+func (dtf *DateTimeFormatter) FormatTemplate(outputTemplate *template.Template) (string, error) {
+	templateView, err := dtf.TemplateView()
+	if err != nil {
+		return "", err
+	}
+
+	var resultBuilder strings.Builder
+	if err = outputTemplate.Execute(&resultBuilder, templateView); err != nil {
+		return "", fmt.Errorf("Unable to execute output template: %s", err)
+	}
+
+	return resultBuilder.String(), nil
+}
+
+// TemplateView returns the values that are available to output templates.  This includes the parts of the date
+// and time, the ISO week, the quarter, Unix values, the zone, and the time rendered in every named format,
+// keyed by its name in TimeFormatToName.  Time holds the time.Time itself, so templates can call its methods,
+// like {{.Time.Format "Jan 2006"}}.
+func (dtf *DateTimeFormatter) TemplateView() (map[string]any, error) {
+	t := dtf.dateTime
+	templateView := map[string]any{}
+
+	for timeFormat, formatName := range TimeFormatToName {
+		if !RendersWithoutLayout(timeFormat) {
+			continue
+		}
+
+		// Formats that can't represent the time, like FileTime before 1601, are left out.  So,
+		// referencing them in a template reports a missing key error.
+		formattedTime, err := dtf.FormatDateTimeWithLayout(timeFormat, "")
+		if err != nil {
+			continue
+		}
+		templateView[formatName] = formattedTime
+	}
+
+	isoYear, isoWeek := t.ISOWeek()
+	zoneAbbreviation, offsetSeconds := t.Zone()
+
+	// locations created for fixed offsets do not have a name
+	zoneName := t.Location().String()
+	if zoneName == "" {
+		zoneName = t.Format("-07:00")
+	}
+
+	hour12 := t.Hour() % 12
+	if hour12 == 0 {
+		hour12 = 12
+	}
+
+	templateView["Time"] = t
+	templateView["Year"] = t.Year()
+	templateView["Month"] = int(t.Month())
+	templateView["MonthName"] = t.Month().String()
+	templateView["MonthShort"] = t.Format("Jan")
+	templateView["Day"] = t.Day()
+	templateView["Hour"] = t.Hour()
+	templateView["Hour12"] = hour12
+	templateView["AMPM"] = t.Format("PM")
+	templateView["Minute"] = t.Minute()
+	templateView["Second"] = t.Second()
+	templateView["Millisecond"] = t.Nanosecond() / int(time.Millisecond)
+	templateView["Microsecond"] = t.Nanosecond() / int(time.Microsecond)
+	templateView["Nanosecond"] = t.Nanosecond()
+	templateView["YearDay"] = t.YearDay()
+	templateView["Weekday"] = t.Weekday().String()
+	templateView["WeekdayShort"] = t.Format("Mon")
+	templateView["WeekdayNumber"] = int(t.Weekday())
+	templateView["ISOWeekday"] = isoWeekday(t)
+	templateView["ISOYear"] = isoYear
+	templateView["ISOWeek"] = isoWeek
+	templateView["Quarter"] = (int(t.Month())-1)/3 + 1
+	templateView["IsLeapYear"] = DaysInMonth(t.Year(), time.February) == 29
+	templateView["UnixSecs"] = t.Unix()
+	templateView["UnixMilli"] = t.UnixMilli()
+	templateView["UnixMicro"] = t.UnixMicro()
+	templateView["UnixNano"] = t.UnixNano()
+	templateView["Zone"] = zoneAbbreviation
+	templateView["ZoneName"] = zoneName
+	templateView["Offset"] = t.Format("-07:00")
+	templateView["OffsetSeconds"] = offsetSeconds
+	templateView["IsDST"] = t.IsDST()
+
+	return templateView, nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2023 - Hoby Smith - hoby@thoughtrealm.com. All rights reserved.
+// Use of this source code is governed by an MIT license that can be found in
+// the LICENSE file found in the project's main folder.
+
+package helpers
+
+import (
+	"time"
+)
+
+// FormatRelative describes t relative to the reference time.  When precise is false, only the largest unit is used,
+// like "3 hours ago" or "in 2 days".  When precise is true, all the units down to seconds are used in a compact form,
+// like "2d 4h 13m ago".
+func FormatRelative(t, reference time.Time, precise bool) string {
+	if precise {
+		return DescribeRelative(t, reference, CalendarDuration.CompactString)
+	}
+
+	return DescribeRelative(t, reference, func(cd CalendarDuration) string {
+		return cd.HumanStringUnits(1)
+	})
+}
+
+// DescribeRelative describes t relative to the reference time, using describe to format the time between them.
+// Times before the reference are "... ago", and times after it are "in ...".  Times less than a second from the
+// reference are "now".
+func DescribeRelative(t, reference time.Time, describe func(CalendarDuration) string) string {
+	elapsed := reference.Sub(t)
+	if elapsed > -time.Second && elapsed < time.Second {
+		return "now"
+	}
+
+	between := CalendarDurationBetween(t, reference)
+	if elapsed < 0 {
+		return "in " + describe(between.Negate())
+	}
+
+	return describe(between) + " ago"
+}
@@ -56,7 +56,7 @@ func BuildFixedLoc(tzOffSet string) (loc *time.Location, err error) {
 		return nil, fmt.Errorf("Minutes is not a valid integer: %s", minsText)
 	}
 
-	if mins < 0 || mins > 23 {
+	if mins < 0 || mins > 59 {
 		return nil, fmt.Errorf("Minutes is out of range: %d.  Must be between 00 and 59", mins)
 	}
 
@@ -67,30 +67,63 @@ func BuildFixedLoc(tzOffSet string) (loc *time.Location, err error) {
 
 // Todo: Maybe add support for abbreviations, perhaps ability to set defaults that indicate which abbreviation to use for ambiguous ones.
 
-// AdjustForOutputTimeZone receives a base time value, then checks to see if the
-// user entered value for output-timezone id one of two timezone types.  It then
-// adjusts the base time according to the determined offset.
+// LoadTimeZone returns the location for a user entered timezone reference.  The tzDesc value
+// describes the usage, like "input" or "output", and is used for error messages.
 // The timezone construction can be one of the following:
 //   - An offset in form of +HHMM
 //   - An IANA timezone name in form region/location.
-func AdjustForOutputTimeZone(baseTime time.Time) (adjustedTime time.Time, err error) {
-	if IsOffsetTZ(CmdHelpers.OutputTimeZone) {
+func LoadTimeZone(tzText, tzDesc string) (loc *time.Location, err error) {
+	if IsOffsetTZ(tzText) {
 		var fixedZone *time.Location
-		fixedZone, err = BuildFixedLoc(CmdHelpers.OutputTimeZone)
+		fixedZone, err = BuildFixedLoc(tzText)
 		if err != nil {
-			return time.Time{}, fmt.Errorf(
+			return nil, fmt.Errorf(
 				"Unable to parse supplied tzOffset: %s. Error: %s",
-				CmdHelpers.OutputTimeZone,
+				tzText,
 				err)
 		}
-		return baseTime.In(fixedZone), nil
+		return fixedZone, nil
 	}
 
 	// not a tz abbrev, nor a tz offset.  Must be an IANA timezone then.
-	tzLoc, err := time.LoadLocation(CmdHelpers.OutputTimeZone)
+	tzLoc, err := time.LoadLocation(tzText)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load indicated %s timezone. Error: %s", tzDesc, err)
+	}
+
+	return tzLoc, nil
+}
+
+// AdjustForOutputTimeZone receives a base time value, then checks to see if the
+// output timezone tzText is one of two timezone types.  It then
+// adjusts the base time according to the determined offset.
+// See LoadTimeZone for the supported timezone constructions.
+func AdjustForOutputTimeZone(baseTime time.Time, tzText string) (adjustedTime time.Time, err error) {
+	tzLoc, err := LoadTimeZone(tzText, "output")
 	if err != nil {
-		return time.Time{}, fmt.Errorf("Unable to load indicated output timezone. Error: %s", err)
+		return time.Time{}, err
 	}
 
 	return baseTime.In(tzLoc), nil
 }
+
+// InputLocation returns the location used when reading input values that do not include a timezone.
+// If no input timezone is set, then UTC is returned, which matches how Go parses values without a timezone.
+// The location is cached, since it may be used for every line of a batch.
+func (hi *HelpersInfo) InputLocation() (*time.Location, error) {
+	if hi.InputTimeZone == "" {
+		return time.UTC, nil
+	}
+
+	if hi.inputLocation == nil || hi.inputLocationName != hi.InputTimeZone {
+		inputLocation, err := LoadTimeZone(hi.InputTimeZone, "input")
+		if err != nil {
+			return nil, err
+		}
+
+		hi.inputLocation = inputLocation
+		hi.inputLocationName = hi.InputTimeZone
+	}
+
+	return hi.inputLocation, nil
+}
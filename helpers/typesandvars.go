@@ -20,8 +20,24 @@ const (
 	ExitCodeErrorDuringInitializeOutputPrinter
 	ExitCodeErrorDecodingInput
 	ExitCodeErrorNoInputProvided
+	ExitCodeAmbiguousInputFormat
 )
 
+// ExitCodeToName maps the ExitCode values to their constant names, which are included in encoded error output
+var ExitCodeToName = map[int]string{
+	ExitCodeSuccess:                            "ExitCodeSuccess",
+	ExitCodePanicInExecute:                     "ExitCodePanicInExecute",
+	ExitCodeErrorReturnedToExecute:             "ExitCodeErrorReturnedToExecute",
+	ExitCodeUnknownErrorInRootCommand:          "ExitCodeUnknownErrorInRootCommand",
+	ExitCodeUnknownOutputTargetName:            "ExitCodeUnknownOutputTargetName",
+	ExitCodeFailureReadingPipeInput:            "ExitCodeFailureReadingPipeInput",
+	ExitCodePanicInUnloadOutputPrinter:         "ExitCodePanicInUnloadOutputPrinter",
+	ExitCodeErrorDuringInitializeOutputPrinter: "ExitCodeErrorDuringInitializeOutputPrinter",
+	ExitCodeErrorDecodingInput:                 "ExitCodeErrorDecodingInput",
+	ExitCodeErrorNoInputProvided:               "ExitCodeErrorNoInputProvided",
+	ExitCodeAmbiguousInputFormat:               "ExitCodeAmbiguousInputFormat",
+}
+
 type OutputMode int
 
 const (
@@ -52,126 +68,341 @@ var OutputTargetNameToType = map[string]OutputTarget{
 	"clipboard": OutputTarget_Clipboard,
 }
 
+type BatchErrorMode int
+
+const (
+	BatchErrorMode_Abort BatchErrorMode = iota
+	BatchErrorMode_PassThrough
+	BatchErrorMode_Marker
+)
+
+var BatchErrorModeNameToMode = map[string]BatchErrorMode{
+	"abort":       BatchErrorMode_Abort,
+	"passthrough": BatchErrorMode_PassThrough,
+	"marker":      BatchErrorMode_Marker,
+}
+
+// BatchErrorMarker replaces lines that could not be converted when the batch error mode is "marker"
+const BatchErrorMarker = "#ERROR"
+
+// MonthEndMode determines how adding months handles days that do not exist in the resulting month, like Jan 31 + 1 month
+type MonthEndMode int
+
+const (
+	// MonthEndMode_Clamp uses the last day of the resulting month, so Jan 31 + 1 month is Feb 28
+	MonthEndMode_Clamp MonthEndMode = iota
+	// MonthEndMode_Overflow uses Go's normalization, so Jan 31 + 1 month is Mar 3
+	MonthEndMode_Overflow
+)
+
+var MonthEndModeNameToMode = map[string]MonthEndMode{
+	"clamp":    MonthEndMode_Clamp,
+	"overflow": MonthEndMode_Overflow,
+}
+
+// TimeScale determines the clock that time values are read or output in.  UTC includes leap seconds, while
+// TAI and GPS time count every second and do not.
+type TimeScale int
+
+const (
+	TimeScale_UTC TimeScale = iota
+	TimeScale_TAI
+	TimeScale_GPS
+)
+
+var TimeScaleNameToScale = map[string]TimeScale{
+	"utc": TimeScale_UTC,
+	"tai": TimeScale_TAI,
+	"gps": TimeScale_GPS,
+}
+
+// OutputEncoding determines whether the root command writes its result as text or as a structured document
+type OutputEncoding int
+
+const (
+	OutputEncoding_Text OutputEncoding = iota
+	OutputEncoding_JSON
+	OutputEncoding_YAML
+)
+
+var OutputEncodingNameToEncoding = map[string]OutputEncoding{
+	"text": OutputEncoding_Text,
+	"json": OutputEncoding_JSON,
+	"yaml": OutputEncoding_YAML,
+}
+
+// SnowflakeLayout describes where the timestamp is in a Snowflake ID.  The timestamp is the milliseconds since
+// EpochMillis, stored in the bits above TimestampShift.
+type SnowflakeLayout struct {
+	EpochMillis    int64
+	TimestampShift uint
+}
+
+// SnowflakeLayoutName_Custom selects a layout built from the snowflake-epoch and snowflake-shift values
+const SnowflakeLayoutName_Custom = "custom"
+
+// DefaultSnowflakeTimestampShift is the timestamp shift used by Twitter and Discord, and the default for custom layouts
+const DefaultSnowflakeTimestampShift = 22
+
+var SnowflakeLayoutNameToLayout = map[string]SnowflakeLayout{
+	"twitter":   {EpochMillis: 1288834974657, TimestampShift: 22},
+	"discord":   {EpochMillis: 1420070400000, TimestampShift: 22},
+	"instagram": {EpochMillis: 1314220021721, TimestampShift: 23},
+}
+
+type DiffStyle int
+
+const (
+	DiffStyle_Go DiffStyle = iota
+	DiffStyle_Secs
+	DiffStyle_Millis
+	DiffStyle_ISO
+	DiffStyle_Human
+)
+
+var DiffStyleNameToStyle = map[string]DiffStyle{
+	"go":     DiffStyle_Go,
+	"secs":   DiffStyle_Secs,
+	"millis": DiffStyle_Millis,
+	"iso":    DiffStyle_ISO,
+	"human":  DiffStyle_Human,
+}
+
 type TimeFormat int
 
 const (
-	TimeFormat_ANSIC            TimeFormat = iota // "Mon Jan _2 15:04:05 2006"
-	TimeFormat_UnixDate                           // "Mon Jan _2 15:04:05 MST 2006"
-	TimeFormat_RubyDate                           // "Mon Jan 02 15:04:05 -0700 2006"
-	TimeFormat_RFC822                             // "02 Jan 06 15:04 MST"
-	TimeFormat_RFC822Z                            // "02 Jan 06 15:04 -0700" // RFC822 with numeric zone
-	TimeFormat_RFC850                             // "Monday, 02-Jan-06 15:04:05 MST"
-	TimeFormat_RFC1123                            // "Mon, 02 Jan 2006 15:04:05 MST"
-	TimeFormat_RFC1123Z                           // "Mon, 02 Jan 2006 15:04:05 -0700" // RFC1123 with numeric zone
-	TimeFormat_RFC3339                            // "2006-01-02T15:04:05Z07:00"
-	TimeFormat_RFC3339Nano                        // "2006-01-02T15:04:05.999999999Z07:00"
-	TimeFormat_Kitchen                            // "3:04PM"
-	TimeFormat_Stamp                              // "Jan _2 15:04:05"
-	TimeFormat_StampMilli                         // "Jan _2 15:04:05.000"
-	TimeFormat_StampMicro                         // "Jan _2 15:04:05.000000"
-	TimeFormat_StampNano                          // "Jan _2 15:04:05.000000000"
-	TimeFormat_USDateTime                         // "2006-01-02 15:04:05"
-	TimeFormat_USDateTimeZ                        // "2006-01-02 15:04:05 -0700"
-	TimeFormat_USDateTimeMilliZ                   // "2006-01-02 15:04:05.000 -0700"
-	TimeFormat_USDateTimeMicroZ                   // "2006-01-02 15:04:05.000000 -0700"
-	TimeFormat_USDateTimeNanoZ                    // "2006-01-02 15:04:05.000000000 -0700"
-	TimeFormat_USDateShort                        // "1/2/06"
-	TimeFormat_USDate                             // "01/02/2006"
-	TimeFormat_EUDateTime                         // "2006-02-01 15:04:05"
-	TimeFormat_EUDateTimeZ                        // "2006-02-01 15:04:05 -0700"
-	TimeFormat_EUDateTimeMilliZ                   // "2006-02-01 15:04:05.000 -0700"
-	TimeFormat_EUDateTimeMicroZ                   // "2006-02-01 15:04:05.000000 -0700"
-	TimeFormat_EUDateTimeNanoZ                    // "2006-02-01 15:04:05.000000000 -0700"
-	TimeFormat_DateOnly                           // "2006-01-02"
-	TimeFormat_TimeOnly                           // "15:04:05"
-	TimeFormat_Custom                             // Specify format with yy/yyyy m/mm/mmm/MMM/Mmm/mmmm/MMMM/Mmmm d/dd/ddd/DDD/Ddd/dddd/DDDD/Dddd h/hh n/nn ss zzz/zzzzzz/zzzzzzzzz tz
-	TimeFormat_CustomGO                           // Specify format with GO layout specs
-	TimeFormat_EUDateShort                        // "2/1/06"
-	TimeFormat_EUDate                             // "02/01/2006"
-	TimeFormat_Unix_Secs                          // Unix Seconds
-	TimeFormat_Unix_Milli                         // Unix Milliseconds
-	TimeFormat_Unix_Micro                         // Unix Microseconds
-	TimeFormat_Unix_Nano                          // Unix Nanoseconds
+	TimeFormat_ANSIC             TimeFormat = iota // "Mon Jan _2 15:04:05 2006"
+	TimeFormat_UnixDate                            // "Mon Jan _2 15:04:05 MST 2006"
+	TimeFormat_RubyDate                            // "Mon Jan 02 15:04:05 -0700 2006"
+	TimeFormat_RFC822                              // "02 Jan 06 15:04 MST"
+	TimeFormat_RFC822Z                             // "02 Jan 06 15:04 -0700" // RFC822 with numeric zone
+	TimeFormat_RFC850                              // "Monday, 02-Jan-06 15:04:05 MST"
+	TimeFormat_RFC1123                             // "Mon, 02 Jan 2006 15:04:05 MST"
+	TimeFormat_RFC1123Z                            // "Mon, 02 Jan 2006 15:04:05 -0700" // RFC1123 with numeric zone
+	TimeFormat_RFC3339                             // "2006-01-02T15:04:05Z07:00"
+	TimeFormat_RFC3339Nano                         // "2006-01-02T15:04:05.999999999Z07:00"
+	TimeFormat_Kitchen                             // "3:04PM"
+	TimeFormat_Stamp                               // "Jan _2 15:04:05"
+	TimeFormat_StampMilli                          // "Jan _2 15:04:05.000"
+	TimeFormat_StampMicro                          // "Jan _2 15:04:05.000000"
+	TimeFormat_StampNano                           // "Jan _2 15:04:05.000000000"
+	TimeFormat_USDateTime                          // "2006-01-02 15:04:05"
+	TimeFormat_USDateTimeZ                         // "2006-01-02 15:04:05 -0700"
+	TimeFormat_USDateTimeMilliZ                    // "2006-01-02 15:04:05.000 -0700"
+	TimeFormat_USDateTimeMicroZ                    // "2006-01-02 15:04:05.000000 -0700"
+	TimeFormat_USDateTimeNanoZ                     // "2006-01-02 15:04:05.000000000 -0700"
+	TimeFormat_USDateShort                         // "1/2/06"
+	TimeFormat_USDate                              // "01/02/2006"
+	TimeFormat_EUDateTime                          // "2006-02-01 15:04:05"
+	TimeFormat_EUDateTimeZ                         // "2006-02-01 15:04:05 -0700"
+	TimeFormat_EUDateTimeMilliZ                    // "2006-02-01 15:04:05.000 -0700"
+	TimeFormat_EUDateTimeMicroZ                    // "2006-02-01 15:04:05.000000 -0700"
+	TimeFormat_EUDateTimeNanoZ                     // "2006-02-01 15:04:05.000000000 -0700"
+	TimeFormat_DateOnly                            // "2006-01-02"
+	TimeFormat_TimeOnly                            // "15:04:05"
+	TimeFormat_Custom                              // Specify format with yy/yyyy m/mm/mmm/MMM/Mmm/mmmm/MMMM/Mmmm d/dd/ddd/DDD/Ddd/dddd/DDDD/Dddd h/hh n/nn ss zzz/zzzzzz/zzzzzzzzz tz
+	TimeFormat_CustomGO                            // Specify format with GO layout specs
+	TimeFormat_EUDateShort                         // "2/1/06"
+	TimeFormat_EUDate                              // "02/01/2006"
+	TimeFormat_Unix_Secs                           // Unix Seconds
+	TimeFormat_Unix_Milli                          // Unix Milliseconds
+	TimeFormat_Unix_Micro                          // Unix Microseconds
+	TimeFormat_Unix_Nano                           // Unix Nanoseconds
+	TimeFormat_Auto                                // Detect the input format from the input value
+	TimeFormat_Relative                            // "3 hours ago" or "in 2 days", relative to now or the relative-to time
+	TimeFormat_RelativePrecise                     // "2d 4h 13m ago", relative to now or the relative-to time
+	TimeFormat_ISOWeekDate                         // "2023-W35-6", ISO 8601 week date
+	TimeFormat_ISOOrdinalDate                      // "2023-244", ISO 8601 ordinal date
+	TimeFormat_ISOBasic                            // "20060102T150405Z0700", ISO 8601 basic date and time
+	TimeFormat_ISO8601                             // Lenient ISO 8601 input, output as "2006-01-02T15:04:05.999999999Z07:00"
+	TimeFormat_FileTime                            // Windows FILETIME, 100ns intervals since 1601-01-01 UTC
+	TimeFormat_FileTimeHex                         // Windows FILETIME as hex, like 0x01D9DD87398CEA00
+	TimeFormat_DotNetTicks                         // .NET DateTime.Ticks, 100ns intervals since 0001-01-01 UTC
+	TimeFormat_Excel1900                           // Excel serial date in the 1900 date system, like 45171.5
+	TimeFormat_Excel1904                           // Excel serial date in the 1904 date system, like 43709.5
+	TimeFormat_Cocoa                               // Cocoa and Core Data absolute time, seconds since 2001-01-01 UTC
+	TimeFormat_WebKit                              // WebKit and Chrome time, microseconds since 1601-01-01 UTC
+	TimeFormat_NTP                                 // NTP timestamp, seconds since 1900-01-01 UTC, like 3902638884.25
+	TimeFormat_NTPHex                              // NTP 64-bit timestamp as hex, like E89D8B24.40000000
+	TimeFormat_GPS                                 // GPS week and seconds of week, like 2277:555702
+	TimeFormat_UUID                                // Input only. The time in a version 1, 6 or 7 UUID
+	TimeFormat_ULID                                // Input only. The time in a ULID
+	TimeFormat_KSUID                               // Input only. The time in a KSUID
+	TimeFormat_ObjectID                            // Input only. The time in a MongoDB ObjectID
+	TimeFormat_Snowflake                           // Input only. The time in a Snowflake ID, using the snowflake layout
+	TimeFormat_UUIDv7Min                           // Output only. The lowest UUIDv7 for the time's millisecond
+	TimeFormat_UUIDv7Max                           // Output only. The highest UUIDv7 for the time's millisecond
+	TimeFormat_ULIDMin                             // Output only. The lowest ULID for the time's millisecond
+	TimeFormat_ULIDMax                             // Output only. The highest ULID for the time's millisecond
+	TimeFormat_SnowflakeMin                        // Output only. The lowest Snowflake ID for the time's millisecond
+	TimeFormat_SnowflakeMax                        // Output only. The highest Snowflake ID for the time's millisecond
+	TimeFormat_UnixSecsFloat                       // Unix Seconds with a decimal fraction, like 1693668084.123456
+	TimeFormat_UnixSecsHex                         // Unix Seconds as hex, like 0x64F352F4
+	TimeFormat_UnixMilliHex                        // Unix Milliseconds as hex
+	TimeFormat_UnixMicroHex                        // Unix Microseconds as hex
+	TimeFormat_UnixNanoHex                         // Unix Nanoseconds as hex
+	TimeFormat_JulianDay                           // Days since noon UTC on 4713 BC January 1 (Julian calendar), like 2460189.931527
+	TimeFormat_ModifiedJulianDay                   // Days since 1858-11-17 UTC, like 60189.431527
+	TimeFormat_RataDie                             // Days since 0000-12-31 UTC (proleptic Gregorian), like 738765.431527
+	TimeFormat_DecimalYear                         // The year with the time as a fraction of the year, like 2023.669675
 )
 
+// DefaultUnixFloatPrecision is the number of decimal places used for UnixSecsFloat output, which is microseconds,
+// and for the day count and DecimalYear formats
+const DefaultUnixFloatPrecision = 6
+
 var NameToTimeFormat = map[string]TimeFormat{
-	"ANSIC":            TimeFormat_ANSIC,
-	"UNIXDATE":         TimeFormat_UnixDate,
-	"RUBYDATE":         TimeFormat_RubyDate,
-	"RFC822":           TimeFormat_RFC822,
-	"RFC822Z":          TimeFormat_RFC822Z,
-	"RFC850":           TimeFormat_RFC850,
-	"RFC1123":          TimeFormat_RFC1123,
-	"RFC1123Z":         TimeFormat_RFC1123Z,
-	"RFC3339":          TimeFormat_RFC3339,
-	"RFC3339NANO":      TimeFormat_RFC3339Nano,
-	"KITCHEN":          TimeFormat_Kitchen,
-	"STAMP":            TimeFormat_Stamp,
-	"STAMPMILLI":       TimeFormat_StampMilli,
-	"STAMPMICRO":       TimeFormat_StampMicro,
-	"STAMPNANO":        TimeFormat_StampNano,
-	"USDATETIME":       TimeFormat_USDateTime,
-	"USDATETIMEZ":      TimeFormat_USDateTimeZ,
-	"USDATETIMEMILLIZ": TimeFormat_USDateTimeMilliZ,
-	"USDATETIMEMICROZ": TimeFormat_USDateTimeMicroZ,
-	"USDATETIMENANOZ":  TimeFormat_USDateTimeNanoZ,
-	"USDATESHORT":      TimeFormat_USDateShort,
-	"USDATE":           TimeFormat_USDate,
-	"EUDATETIME":       TimeFormat_EUDateTime,
-	"EUDATETIMEZ":      TimeFormat_EUDateTimeZ,
-	"EUDATETIMEMILLIZ": TimeFormat_EUDateTimeMilliZ,
-	"EUDATETIMEMICROZ": TimeFormat_EUDateTimeMicroZ,
-	"EUDATETIMENANOZ":  TimeFormat_EUDateTimeNanoZ,
-	"EUDATESHORT":      TimeFormat_EUDateShort,
-	"EUDATE":           TimeFormat_EUDate,
-	"DATEONLY":         TimeFormat_DateOnly,
-	"TIMEONLY":         TimeFormat_TimeOnly,
-	"CUSTOM":           TimeFormat_Custom,
-	"CUSTOMGO":         TimeFormat_CustomGO,
-	"UNIXSECS":         TimeFormat_Unix_Secs,
-	"UNIXMILLI":        TimeFormat_Unix_Milli,
-	"UNIXMICRO":        TimeFormat_Unix_Micro,
-	"UNIXNANO":         TimeFormat_Unix_Nano,
+	"ANSIC":             TimeFormat_ANSIC,
+	"UNIXDATE":          TimeFormat_UnixDate,
+	"RUBYDATE":          TimeFormat_RubyDate,
+	"RFC822":            TimeFormat_RFC822,
+	"RFC822Z":           TimeFormat_RFC822Z,
+	"RFC850":            TimeFormat_RFC850,
+	"RFC1123":           TimeFormat_RFC1123,
+	"RFC1123Z":          TimeFormat_RFC1123Z,
+	"RFC3339":           TimeFormat_RFC3339,
+	"RFC3339NANO":       TimeFormat_RFC3339Nano,
+	"KITCHEN":           TimeFormat_Kitchen,
+	"STAMP":             TimeFormat_Stamp,
+	"STAMPMILLI":        TimeFormat_StampMilli,
+	"STAMPMICRO":        TimeFormat_StampMicro,
+	"STAMPNANO":         TimeFormat_StampNano,
+	"USDATETIME":        TimeFormat_USDateTime,
+	"USDATETIMEZ":       TimeFormat_USDateTimeZ,
+	"USDATETIMEMILLIZ":  TimeFormat_USDateTimeMilliZ,
+	"USDATETIMEMICROZ":  TimeFormat_USDateTimeMicroZ,
+	"USDATETIMENANOZ":   TimeFormat_USDateTimeNanoZ,
+	"USDATESHORT":       TimeFormat_USDateShort,
+	"USDATE":            TimeFormat_USDate,
+	"EUDATETIME":        TimeFormat_EUDateTime,
+	"EUDATETIMEZ":       TimeFormat_EUDateTimeZ,
+	"EUDATETIMEMILLIZ":  TimeFormat_EUDateTimeMilliZ,
+	"EUDATETIMEMICROZ":  TimeFormat_EUDateTimeMicroZ,
+	"EUDATETIMENANOZ":   TimeFormat_EUDateTimeNanoZ,
+	"EUDATESHORT":       TimeFormat_EUDateShort,
+	"EUDATE":            TimeFormat_EUDate,
+	"DATEONLY":          TimeFormat_DateOnly,
+	"TIMEONLY":          TimeFormat_TimeOnly,
+	"CUSTOM":            TimeFormat_Custom,
+	"CUSTOMGO":          TimeFormat_CustomGO,
+	"UNIXSECS":          TimeFormat_Unix_Secs,
+	"UNIXMILLI":         TimeFormat_Unix_Milli,
+	"UNIXMICRO":         TimeFormat_Unix_Micro,
+	"UNIXNANO":          TimeFormat_Unix_Nano,
+	"AUTO":              TimeFormat_Auto,
+	"RELATIVE":          TimeFormat_Relative,
+	"RELATIVEPRECISE":   TimeFormat_RelativePrecise,
+	"ISOWEEKDATE":       TimeFormat_ISOWeekDate,
+	"ISOORDINALDATE":    TimeFormat_ISOOrdinalDate,
+	"ISOBASIC":          TimeFormat_ISOBasic,
+	"ISO8601":           TimeFormat_ISO8601,
+	"FILETIME":          TimeFormat_FileTime,
+	"FILETIMEHEX":       TimeFormat_FileTimeHex,
+	"DOTNETTICKS":       TimeFormat_DotNetTicks,
+	"EXCEL1900":         TimeFormat_Excel1900,
+	"EXCEL1904":         TimeFormat_Excel1904,
+	"COCOA":             TimeFormat_Cocoa,
+	"WEBKIT":            TimeFormat_WebKit,
+	"NTP":               TimeFormat_NTP,
+	"NTPHEX":            TimeFormat_NTPHex,
+	"GPS":               TimeFormat_GPS,
+	"UUID":              TimeFormat_UUID,
+	"ULID":              TimeFormat_ULID,
+	"KSUID":             TimeFormat_KSUID,
+	"OBJECTID":          TimeFormat_ObjectID,
+	"SNOWFLAKE":         TimeFormat_Snowflake,
+	"UUIDV7MIN":         TimeFormat_UUIDv7Min,
+	"UUIDV7MAX":         TimeFormat_UUIDv7Max,
+	"ULIDMIN":           TimeFormat_ULIDMin,
+	"ULIDMAX":           TimeFormat_ULIDMax,
+	"SNOWFLAKEMIN":      TimeFormat_SnowflakeMin,
+	"SNOWFLAKEMAX":      TimeFormat_SnowflakeMax,
+	"UNIXSECSFLOAT":     TimeFormat_UnixSecsFloat,
+	"UNIXSECSHEX":       TimeFormat_UnixSecsHex,
+	"UNIXMILLIHEX":      TimeFormat_UnixMilliHex,
+	"UNIXMICROHEX":      TimeFormat_UnixMicroHex,
+	"UNIXNANOHEX":       TimeFormat_UnixNanoHex,
+	"JULIANDAY":         TimeFormat_JulianDay,
+	"MODIFIEDJULIANDAY": TimeFormat_ModifiedJulianDay,
+	"RATADIE":           TimeFormat_RataDie,
+	"DECIMALYEAR":       TimeFormat_DecimalYear,
 }
 
 var TimeFormatToName = map[TimeFormat]string{
-	TimeFormat_ANSIC:            "ANSIC",
-	TimeFormat_UnixDate:         "UnixDate",
-	TimeFormat_RubyDate:         "RubyDate",
-	TimeFormat_RFC822:           "RFC822",
-	TimeFormat_RFC822Z:          "RFC822Z",
-	TimeFormat_RFC850:           "RFC850",
-	TimeFormat_RFC1123:          "RFC1123",
-	TimeFormat_RFC1123Z:         "RFC1123Z",
-	TimeFormat_RFC3339:          "RFC3339",
-	TimeFormat_RFC3339Nano:      "RFC3339Nano",
-	TimeFormat_Kitchen:          "Kitchen",
-	TimeFormat_Stamp:            "Stamp",
-	TimeFormat_StampMilli:       "StampMilli",
-	TimeFormat_StampMicro:       "StampMicro",
-	TimeFormat_StampNano:        "StampNano",
-	TimeFormat_USDateTime:       "USDateTime",
-	TimeFormat_USDateTimeZ:      "USDateTimeZ",
-	TimeFormat_USDateTimeMilliZ: "USDateTimeMilliZ",
-	TimeFormat_USDateTimeMicroZ: "USDateTimeMicroZ",
-	TimeFormat_USDateTimeNanoZ:  "USDateTimeNanoZ",
-	TimeFormat_USDateShort:      "USDateShort",
-	TimeFormat_USDate:           "USDate",
-	TimeFormat_EUDateTime:       "EUDateTime",
-	TimeFormat_EUDateTimeZ:      "EUDateTimeZ",
-	TimeFormat_EUDateTimeMilliZ: "EUDateTimeMilliZ",
-	TimeFormat_EUDateTimeMicroZ: "EUDateTimeMicroZ",
-	TimeFormat_EUDateTimeNanoZ:  "EUDateTimeNanoZ",
-	TimeFormat_EUDateShort:      "EUDateShort",
-	TimeFormat_EUDate:           "EUDate",
-	TimeFormat_DateOnly:         "DateOnly",
-	TimeFormat_TimeOnly:         "TimeOnly",
-	TimeFormat_Custom:           "Custom",
-	TimeFormat_CustomGO:         "CustomGO",
-	TimeFormat_Unix_Secs:        "UnixSecs",
-	TimeFormat_Unix_Milli:       "UnixMilli",
-	TimeFormat_Unix_Micro:       "UnixMicro",
-	TimeFormat_Unix_Nano:        "UnixNano",
+	TimeFormat_ANSIC:             "ANSIC",
+	TimeFormat_UnixDate:          "UnixDate",
+	TimeFormat_RubyDate:          "RubyDate",
+	TimeFormat_RFC822:            "RFC822",
+	TimeFormat_RFC822Z:           "RFC822Z",
+	TimeFormat_RFC850:            "RFC850",
+	TimeFormat_RFC1123:           "RFC1123",
+	TimeFormat_RFC1123Z:          "RFC1123Z",
+	TimeFormat_RFC3339:           "RFC3339",
+	TimeFormat_RFC3339Nano:       "RFC3339Nano",
+	TimeFormat_Kitchen:           "Kitchen",
+	TimeFormat_Stamp:             "Stamp",
+	TimeFormat_StampMilli:        "StampMilli",
+	TimeFormat_StampMicro:        "StampMicro",
+	TimeFormat_StampNano:         "StampNano",
+	TimeFormat_USDateTime:        "USDateTime",
+	TimeFormat_USDateTimeZ:       "USDateTimeZ",
+	TimeFormat_USDateTimeMilliZ:  "USDateTimeMilliZ",
+	TimeFormat_USDateTimeMicroZ:  "USDateTimeMicroZ",
+	TimeFormat_USDateTimeNanoZ:   "USDateTimeNanoZ",
+	TimeFormat_USDateShort:       "USDateShort",
+	TimeFormat_USDate:            "USDate",
+	TimeFormat_EUDateTime:        "EUDateTime",
+	TimeFormat_EUDateTimeZ:       "EUDateTimeZ",
+	TimeFormat_EUDateTimeMilliZ:  "EUDateTimeMilliZ",
+	TimeFormat_EUDateTimeMicroZ:  "EUDateTimeMicroZ",
+	TimeFormat_EUDateTimeNanoZ:   "EUDateTimeNanoZ",
+	TimeFormat_EUDateShort:       "EUDateShort",
+	TimeFormat_EUDate:            "EUDate",
+	TimeFormat_DateOnly:          "DateOnly",
+	TimeFormat_TimeOnly:          "TimeOnly",
+	TimeFormat_Custom:            "Custom",
+	TimeFormat_CustomGO:          "CustomGO",
+	TimeFormat_Unix_Secs:         "UnixSecs",
+	TimeFormat_Unix_Milli:        "UnixMilli",
+	TimeFormat_Unix_Micro:        "UnixMicro",
+	TimeFormat_Unix_Nano:         "UnixNano",
+	TimeFormat_Auto:              "Auto",
+	TimeFormat_Relative:          "Relative",
+	TimeFormat_RelativePrecise:   "RelativePrecise",
+	TimeFormat_ISOWeekDate:       "ISOWeekDate",
+	TimeFormat_ISOOrdinalDate:    "ISOOrdinalDate",
+	TimeFormat_ISOBasic:          "ISOBasic",
+	TimeFormat_ISO8601:           "ISO8601",
+	TimeFormat_FileTime:          "FileTime",
+	TimeFormat_FileTimeHex:       "FileTimeHex",
+	TimeFormat_DotNetTicks:       "DotNetTicks",
+	TimeFormat_Excel1900:         "Excel1900",
+	TimeFormat_Excel1904:         "Excel1904",
+	TimeFormat_Cocoa:             "Cocoa",
+	TimeFormat_WebKit:            "WebKit",
+	TimeFormat_NTP:               "NTP",
+	TimeFormat_NTPHex:            "NTPHex",
+	TimeFormat_GPS:               "GPS",
+	TimeFormat_UUID:              "UUID",
+	TimeFormat_ULID:              "ULID",
+	TimeFormat_KSUID:             "KSUID",
+	TimeFormat_ObjectID:          "ObjectID",
+	TimeFormat_Snowflake:         "Snowflake",
+	TimeFormat_UUIDv7Min:         "UUIDv7Min",
+	TimeFormat_UUIDv7Max:         "UUIDv7Max",
+	TimeFormat_ULIDMin:           "ULIDMin",
+	TimeFormat_ULIDMax:           "ULIDMax",
+	TimeFormat_SnowflakeMin:      "SnowflakeMin",
+	TimeFormat_SnowflakeMax:      "SnowflakeMax",
+	TimeFormat_UnixSecsFloat:     "UnixSecsFloat",
+	TimeFormat_UnixSecsHex:       "UnixSecsHex",
+	TimeFormat_UnixMilliHex:      "UnixMilliHex",
+	TimeFormat_UnixMicroHex:      "UnixMicroHex",
+	TimeFormat_UnixNanoHex:       "UnixNanoHex",
+	TimeFormat_JulianDay:         "JulianDay",
+	TimeFormat_ModifiedJulianDay: "ModifiedJulianDay",
+	TimeFormat_RataDie:           "RataDie",
+	TimeFormat_DecimalYear:       "DecimalYear",
 }
 
 var TimeFormatToLayout = map[TimeFormat]string{
@@ -206,6 +437,7 @@ var TimeFormatToLayout = map[TimeFormat]string{
 	TimeFormat_EUDate:           "02/01/2006",
 	TimeFormat_DateOnly:         "2006-01-02",
 	TimeFormat_TimeOnly:         "15:04:05",
+	TimeFormat_ISOBasic:         "20060102T150405Z0700",
 }
 
 type tokenFragment struct {